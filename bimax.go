@@ -3,6 +3,7 @@ package bimax
 import (
 	"fmt"
 
+	"github.com/maxsei/bimax/set"
 	"github.com/yourbasic/graph"
 )
 
@@ -10,11 +11,20 @@ import (
 // rows and m the number of columns.  The data for the binary matrix is
 // specified as a slice of uint8 containing only 1's and 0's.
 func BiMaxBinaryMatrix(n, m int, data []uint8) *BiMaxResult {
+	G, U, V := BinaryMatrixGraph(n, m, data)
+	// Get the result of the bimax Function
+	return BiMax(G, U, V)
+}
+
+// BinaryMatrixGraph builds the bipartite graph G of an n by m binary matrix
+// along with its vertex sets U and V.  Rows are given the vertices [0, n) and
+// columns the vertices [n, n+m).
+func BinaryMatrixGraph(n, m int, data []uint8) (G *graph.Mutable, U, V *UnorderedSet) {
 	if (len(data) / m) != n {
 		panic(fmt.Sprintf("matrix data cannot be reshaped into [%d, %d]", n, m))
 	}
-	G := graph.New(n + m)
-	U, V := NewSet(), NewSet()
+	G = graph.New(n + m)
+	U, V = NewSet(), NewSet()
 
 	for i, x := range data {
 		switch x {
@@ -32,8 +42,7 @@ func BiMaxBinaryMatrix(n, m int, data []uint8) *BiMaxResult {
 			panic(fmt.Sprintf("%d is not a zero or 1", x))
 		}
 	}
-	// Get the result of the bimax Function
-	return BiMax(G, U, V)
+	return
 }
 
 // BiMaxVertices takes in two slices of verticies uu and vv that represents sets
@@ -44,24 +53,57 @@ func BiMaxBinaryMatrix(n, m int, data []uint8) *BiMaxResult {
 // The edge set of U and V makes up the graph G such that every vertex in set U
 // must map to some vertex in set V and vice versa
 func BiMaxVertices(uu, vv []int) *BiMaxResult {
+	G, U, V := VerticesGraph(uu, vv)
+	return BiMax(G, U, V)
+}
+
+// VerticesGraph builds the bipartite graph G whose edges join uu[i] and vv[i]
+// along with its vertex sets U and V.  The vertices of U and V share one
+// numbering, so no vertex may be in both, and none may be negative.
+func VerticesGraph(uu, vv []int) (G *graph.Mutable, U, V *UnorderedSet) {
 	if len(uu) != len(vv) {
 		panic(fmt.Sprintf("len(uu): %d len(vv): %d must be equal", len(uu), len(vv)))
 	}
-	U, V := NewSetFromSlice(uu), NewSetFromSlice(vv)
-	// Find the maximal vertex index in the set ov V to be used the max number of
+	U, V = NewSetFromSlice(uu), NewSetFromSlice(vv)
+	// Find the maximal vertex index of U and V to be used the max number of
 	// vertecies in the Graph
-	var vtxCount int
-	V.Each(func(v int) (_ bool) {
-		if v > vtxCount {
-			vtxCount = v
+	vtxCount := 0
+	for i := range uu {
+		if uu[i] < 0 || vv[i] < 0 {
+			panic(fmt.Sprintf("edge %d: (%d, %d) has a negative vertex", i, uu[i], vv[i]))
 		}
-		return
-	})
-	G := graph.New(vtxCount + 1)
+		if V.Has(uu[i]) {
+			panic(fmt.Sprintf("vertex %d is in both U and V", uu[i]))
+		}
+		vtxCount = max(vtxCount, uu[i]+1, vv[i]+1)
+	}
+	G = graph.New(vtxCount)
 	for i := 0; i < len(uu); i++ {
 		G.AddBoth(uu[i], vv[i])
 	}
-	return BiMax(G, U, V)
+	return
+}
+
+// EdgeListGraph builds the bipartite graph G of an edge list whose vertices
+// u ∈ U and v ∈ V are numbered independently, so that the same number may name
+// both a row and a column.  The vertices of V are shifted by offset, one more
+// than the greatest vertex of U, to keep them apart from U.
+func EdgeListGraph(uu, vv []int) (G *graph.Mutable, U, V *UnorderedSet, offset int) {
+	if len(uu) != len(vv) {
+		panic(fmt.Sprintf("len(uu): %d len(vv): %d must be equal", len(uu), len(vv)))
+	}
+	for _, u := range uu {
+		offset = max(offset, u+1)
+	}
+	shifted := make([]int, len(vv))
+	for i, v := range vv {
+		if v < 0 {
+			panic(fmt.Sprintf("edge %d: (%d, %d) has a negative vertex", i, uu[i], v))
+		}
+		shifted[i] = v + offset
+	}
+	G, U, V = VerticesGraph(uu, shifted)
+	return
 }
 
 // BiMaxResult represents the result returned from 'BiMax' as a set of boths
@@ -69,52 +111,97 @@ func BiMaxVertices(uu, vv []int) *BiMaxResult {
 // graph G.
 type BiMaxResult struct {
 	Rows, Cols *SetOp
+	// Weight is the value of the objective achieved by the biclique.  For
+	// 'BiMax' this is the area, the number of rows times the number of columns.
+	Weight float64
+	// PValue is the significance of the biclique as computed by
+	// 'Significance'.  It is zero until the significance has been computed.
+	PValue float64
 }
 
 // BiMax finds the maximal bipartitie clique of a bipartite graph of graph G
 // where G is a bipartite graph of (U ∪ V, E(G))
 func BiMax(G *graph.Mutable, L, PU *UnorderedSet) *BiMaxResult {
+	return BiMaxObjective(G, L, PU, Area)
+}
+
+// BiMaxObjective finds the maximal biclique of the bipartite graph G that has
+// the greatest value of objective.  Ties are broken as in 'SortResults' so that
+// the same biclique is found on every run.
+func BiMaxObjective(G *graph.Mutable, L, PU *UnorderedSet, objective Objective) *BiMaxResult {
+	// Resulting sets
+	result := &BiMaxResult{Rows: NewSet().SetOp, Cols: NewSet().SetOp}
+	Enumerate(G, L, PU, func(rows, cols *UnorderedSet) (_ bool) {
+		w := objective(rows.SetOp, cols.SetOp)
+		if w < result.Weight || w == 0 {
+			return
+		}
+		r := &BiMaxResult{Rows: &SetOp{Set: rows}, Cols: &SetOp{Set: cols}, Weight: w}
+		if w > result.Weight || lessResult(r, result) {
+			result = r
+		}
+		return
+	})
+	return result
+}
+
+// Enumerate calls report with the rows ∈ L and columns ∈ PU of every maximal
+// biclique of the bipartite graph G until report returns done.  The sets
+// passed to report must not be modified.
+func Enumerate(G *graph.Mutable, L, PU *UnorderedSet, report func(rows, cols *UnorderedSet) (done bool)) {
+	enumerate(G, L.SetOp, PU.SetOp, func(rows, cols *SetOp) (done bool) {
+		return report(rows.Set.(*UnorderedSet), cols.Set.(*UnorderedSet))
+	})
+}
+
+// enumerate is 'Enumerate' for sets of any kind.  The rows and columns reported
+// are sets of the same kind as L and PU respectively.
+func enumerate(G *graph.Mutable, L, PU *SetOp, report func(rows, cols *SetOp) (done bool)) {
 	// L: is a set of verticies ∈ U that are common neigbors of R; initially L = U
 	// R: is a set of verticies ∈ V belonging to the current biclique; initially
 	// empty
-	R := NewSet()
+	R := &SetOp{Set: PU.New()}
 	// P: is a set of verticies ∈ V that can be added to R, initially P = V,
 	// sorted by non-decreasing order of neigborhood size
-	P := PU.Order(func(v1, v2 int) bool {
-		return G.Degree(v2) <= G.Degree(v1)
+	P := NewOrderedSetWithCapacity(func(v1, v2 int) bool {
+		return G.Degree(v1) <= G.Degree(v2)
+	}, PU.Card())
+	PU.Each(func(v int) (_ bool) {
+		P.Add(v)
+		return
 	})
 
 	// Q: is a set of verticies used to determine maximality, initially empty
-	Q := NewSet()
+	Q := &SetOp{Set: PU.New()}
 
-	// Resulting sets
-	Rows, Cols := NewSet(), NewSet()
+	// stop is set once report is done with the enumeration.
+	stop := false
 
-	var bicliqueFind func(P *OrderedSet, L, R, Q *UnorderedSet)
-	bicliqueFind = func(P *OrderedSet, L, R, Q *UnorderedSet) {
-		for i := 0; i < P.Card(); i++ {
+	var bicliqueFind func(P *OrderedSet, L, R, Q *SetOp)
+	bicliqueFind = func(P *OrderedSet, L, R, Q *SetOp) {
+		// Every candidate x is moved from P to Q once it has been explored so
+		// the loop ends when P is empty.
+		for P.Card() > 0 && !stop {
 			x := P.Get(0)
 
 			// Candidates
-			c := NewSetWith(x)
+			c := &SetOp{Set: PU.New()}
+			c.Add(x)
 			// Rʹ is set of verticies in current biclique
-			Rʹ := R.Union(c)
+			Rʹ := &SetOp{Set: set.Union(R.Set, c)}
 			// Lʹ is the set verticies in L that neighbor x
-			Lʹ := NeighborSet(x, L.SetOp, G, false).(*UnorderedSet)
+			Lʹ := &SetOp{Set: NeighborSet(x, L, G, false)}
 			// Complement of Lʹ
-			Lʹᶜ := L.Difference(Lʹ)
+			Lʹᶜ := &SetOp{Set: set.Difference(L.Set, Lʹ)}
 
 			// Create new sets for P and Q
-			Pʹ, Qʹ := P.New().(*OrderedSet), Q.New().(*UnorderedSet)
+			Pʹ, Qʹ := P.New().(*OrderedSet), &SetOp{Set: Q.New()}
 
-			// var bicliqueFind func(Pʹ *OrderedSet, Lʹ, Rʹ, Qʹ *UnorderedSet)
-			// bicliqueFind = func(Pʹ *OrderedSet, Lʹ, Rʹ, Qʹ *UnorderedSet) {
 			maximal := true
 			// For all v in Q
 			Q.Each(func(v int) (done bool) {
 				// Cardinality of closed neighborhood at v is the the degree + 1
-				LʹNeighborVDegree := NeighborSetDegree(v, Lʹ.SetOp, G, false)
-				// N := NeighborSet(*v, Lʹ, G, false)
+				LʹNeighborVDegree := NeighborSetDegree(v, Lʹ, G, false)
 				if LʹNeighborVDegree == Lʹ.Card() {
 					maximal = false
 					return true
@@ -134,18 +221,18 @@ func BiMax(G *graph.Mutable, L, PU *UnorderedSet) *BiMaxResult {
 
 					// Set of {uϵLʹ| (u, v) ϵ E(G)} set of verticies u such that u and v
 					// are edges in graph G including v
-					// N := NeighborSet(*v, Lʹ, G, false)
-					N := NeighborSet(v, Lʹ.SetOp, G, false)
-					if N.Card() == Lʹ.Card() {
+					N := NeighborSet(v, Lʹ, G, false)
+					if N.Len() == Lʹ.Card() {
 						Rʹ.Add(v)
 						// Set of {uϵLʹᶜ| (u, v) ϵ E(G)} set of verticies u such that u and v
 						// are edges in graph G
-						if NeighborSetDegree(v, Lʹᶜ.SetOp, G, false) == 0 {
+						if NeighborSetDegree(v, Lʹᶜ, G, false) == 0 {
 							c.Add(v)
 						}
 						return
 					}
-					if N.Card() == 0 {
+					// Only candidates with a neighbor in Lʹ can extend the biclique.
+					if N.Len() > 0 {
 						Pʹ.Add(v)
 					}
 					return
@@ -153,17 +240,12 @@ func BiMax(G *graph.Mutable, L, PU *UnorderedSet) *BiMaxResult {
 				// TODO: might be able to optimize based on number of enumerated
 				// bicliques <20-01-21, Max Schulte> //
 
-				// Print Maximal biclique
-				// fmt.Println(Lʹ, Rʹ)
-
-				if (Rows.Card() * Cols.Card()) < (Lʹ.Card() * Rʹ.Card()) {
-					// A = Lʹ.Copy()
-					// B = Rʹ.Copy()
-					Rows = Lʹ
-					Cols = Rʹ
+				// Report maximal biclique
+				if Lʹ.Card() > 0 && report(Lʹ, Rʹ) {
+					stop = true
+					return
 				}
-				// fmt.Println(Lʹ, Rʹ)
-				if Pʹ.Card() == 0 {
+				if Pʹ.Card() != 0 {
 					bicliqueFind(Pʹ, Lʹ, Rʹ, Qʹ)
 				}
 			}
@@ -173,8 +255,6 @@ func BiMax(G *graph.Mutable, L, PU *UnorderedSet) *BiMaxResult {
 		}
 	}
 	bicliqueFind(P, L, R, Q)
-	result := BiMaxResult{&SetOp{Rows}, &SetOp{Cols}}
-	return &result
 }
 
 // ClosedDegree returns the degree of the closed neighborhood at v
@@ -202,7 +282,7 @@ func NeighborSetDegree(v int, set *SetOp, G *graph.Mutable, closed bool) int {
 }
 
 func NeighborSet(v int, set *SetOp, G *graph.Mutable, closed bool) Set {
-	result := &SetOp{set.New()}
+	result := &SetOp{Set: set.New()}
 	if closed {
 		result.Add(v)
 	}
@@ -0,0 +1,321 @@
+package bimax_test
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/generate"
+)
+
+// randomMatrices returns matrices of random size and density for property
+// testing against the brute force enumeration.
+func randomMatrices(count int) (matrices []matrix) {
+	rng := rand.New(rand.NewSource(1))
+	for seed := int64(0); seed < int64(count); seed++ {
+		n, m := 1+rng.Intn(10), 1+rng.Intn(8)
+		p := 0.2 + 0.7*rng.Float64()
+		data, err := generate.ErdosRenyi(n, m, p, seed)
+		if err != nil {
+			panic(err)
+		}
+		matrices = append(matrices, matrix{n, m, data})
+	}
+	return
+}
+
+type matrix struct {
+	n, m int
+	data []uint8
+}
+
+func (x matrix) String() string { return fmt.Sprintf("[%d, %d]%v", x.n, x.m, x.data) }
+
+// mask converts a result of a matrix into bit masks of rows and columns.
+func (x matrix) mask(r *bimax.BiMaxResult) (b biclique) {
+	r.Rows.Each(func(i int) (_ bool) {
+		b.rows |= 1 << uint(i)
+		return
+	})
+	r.Cols.Each(func(j int) (_ bool) {
+		b.cols |= 1 << uint(j-x.n)
+		return
+	})
+	return
+}
+
+// checkMaximal fails the test unless b is a biclique of x that no row or
+// column can be added to.
+func (x matrix) checkMaximal(t *testing.T, b biclique) {
+	t.Helper()
+	for i := 0; i < x.n; i++ {
+		covered := true
+		for j := 0; j < x.m; j++ {
+			if b.cols&(1<<uint(j)) != 0 && x.data[i*x.m+j] == 0 {
+				covered = false
+			}
+		}
+		if has := b.rows&(1<<uint(i)) != 0; has != covered {
+			t.Fatalf("%v: row %d breaks the maximal biclique %b x %b", x, i, b.rows, b.cols)
+		}
+	}
+	for j := 0; j < x.m; j++ {
+		covered := true
+		for i := 0; i < x.n; i++ {
+			if b.rows&(1<<uint(i)) != 0 && x.data[i*x.m+j] == 0 {
+				covered = false
+			}
+		}
+		if has := b.cols&(1<<uint(j)) != 0; has != covered {
+			t.Fatalf("%v: column %d breaks the maximal biclique %b x %b", x, j, b.rows, b.cols)
+		}
+	}
+}
+
+// best returns the greatest score of any maximal biclique of x.
+func (x matrix) best(score func(b biclique) float64) (best float64) {
+	for _, b := range bruteForce(x.n, x.m, x.data) {
+		best = math.Max(best, score(b))
+	}
+	return
+}
+
+func TestBiMaxBinaryMatrix(t *testing.T) {
+	for _, x := range randomMatrices(500) {
+		r := bimax.BiMaxBinaryMatrix(x.n, x.m, x.data)
+		want := x.best(func(b biclique) float64 { return float64(b.area()) })
+		if r.Weight != want {
+			t.Fatalf("%v: got area %v want %v", x, r.Weight, want)
+		}
+		if want == 0 {
+			continue
+		}
+		b := x.mask(r)
+		if float64(b.area()) != r.Weight {
+			t.Fatalf("%v: weight %v is not the area of %v x %v", x, r.Weight, r.Rows, r.Cols)
+		}
+		x.checkMaximal(t, b)
+	}
+}
+
+func TestBiMaxWeightedMatrix(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, x := range randomMatrices(300) {
+		weights := make([]float64, len(x.data))
+		for i, v := range x.data {
+			if v == 1 {
+				weights[i] = float64(1 + rng.Intn(9))
+			}
+		}
+		score := func(b biclique) (total float64) {
+			for i := 0; i < x.n; i++ {
+				for j := 0; j < x.m; j++ {
+					if b.rows&(1<<uint(i)) != 0 && b.cols&(1<<uint(j)) != 0 {
+						total += weights[i*x.m+j]
+					}
+				}
+			}
+			return
+		}
+		r := bimax.BiMaxWeightedMatrix(x.n, x.m, weights)
+		if want := x.best(score); r.Weight != want {
+			t.Fatalf("%v %v: got weight %v want %v", x, weights, r.Weight, want)
+		}
+		if r.Weight == 0 {
+			continue
+		}
+		b := x.mask(r)
+		if score(b) != r.Weight {
+			t.Fatalf("%v: weight %v is not the weight of %v x %v", x, r.Weight, r.Rows, r.Cols)
+		}
+		x.checkMaximal(t, b)
+	}
+}
+
+func TestBiMaxVertexWeightedMatrix(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, x := range randomMatrices(300) {
+		rowWeights, colWeights := make([]float64, x.n), make([]float64, x.m)
+		for i := range rowWeights {
+			rowWeights[i] = float64(rng.Intn(5))
+		}
+		for j := range colWeights {
+			colWeights[j] = float64(rng.Intn(5))
+		}
+		score := func(b biclique) (total float64) {
+			for i, w := range rowWeights {
+				if b.rows&(1<<uint(i)) != 0 {
+					total += w
+				}
+			}
+			for j, w := range colWeights {
+				if b.cols&(1<<uint(j)) != 0 {
+					total += w
+				}
+			}
+			return
+		}
+		r := bimax.BiMaxVertexWeightedMatrix(x.n, x.m, x.data, rowWeights, colWeights)
+		if want := x.best(score); r.Weight != want {
+			t.Fatalf("%v: got weight %v want %v", x, r.Weight, want)
+		}
+		if r.Weight == 0 {
+			continue
+		}
+		b := x.mask(r)
+		if score(b) != r.Weight {
+			t.Fatalf("%v: weight %v is not the weight of %v x %v", x, r.Weight, r.Rows, r.Cols)
+		}
+		x.checkMaximal(t, b)
+	}
+}
+
+func TestBiMaxVertices(t *testing.T) {
+	// Two disjoint bicliques of 2 x 3 and 3 x 3 with the columns numbered after
+	// the rows.
+	uu := []int{0, 0, 0, 1, 1, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4}
+	vv := []int{5, 6, 7, 5, 6, 7, 8, 9, 10, 8, 9, 10, 8, 9, 10}
+	r := bimax.BiMaxVertices(uu, vv)
+	if !r.Rows.IsEqual(bimax.NewSetWith(2, 3, 4)) || !r.Cols.IsEqual(bimax.NewSetWith(8, 9, 10)) {
+		t.Fatalf("got %v x %v", r.Rows, r.Cols)
+	}
+}
+
+func TestVerticesGraph(t *testing.T) {
+	// The graph is sized by the greatest vertex of U as well as of V.
+	G, U, V := bimax.VerticesGraph([]int{5, 5}, []int{1, 2})
+	if G.Order() != 6 || !G.Edge(5, 1) || U.Card() != 1 || V.Card() != 2 {
+		t.Fatalf("got order %d with U %v and V %v", G.Order(), U, V)
+	}
+	for _, edges := range [][2][]int{
+		{{0, 1}, {1, 2}},
+		{{-1}, {2}},
+		{{0}, {1, 2}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v: expected a panic", edges)
+				}
+			}()
+			bimax.VerticesGraph(edges[0], edges[1])
+		}()
+	}
+}
+
+func TestEdgeListGraph(t *testing.T) {
+	G, U, V, offset := bimax.EdgeListGraph([]int{0, 0, 1, 1}, []int{0, 1, 0, 1})
+	if offset != 2 || !U.IsEqual(bimax.NewSetWith(0, 1)) || !V.IsEqual(bimax.NewSetWith(2, 3)) {
+		t.Fatalf("got offset %d with U %v and V %v", offset, U, V)
+	}
+	r := bimax.BiMax(G, U, V)
+	if r.Weight != 4 {
+		t.Fatalf("got %v x %v", r.Rows, r.Cols)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	for _, x := range randomMatrices(300) {
+		want := make(map[biclique]bool)
+		for _, b := range bruteForce(x.n, x.m, x.data) {
+			want[b] = true
+		}
+		G, U, V := bimax.BinaryMatrixGraph(x.n, x.m, x.data)
+		got := make(map[biclique]bool)
+		bimax.Enumerate(G, U, V, func(rows, cols *bimax.UnorderedSet) (_ bool) {
+			b := x.mask(&bimax.BiMaxResult{Rows: rows.SetOp, Cols: cols.SetOp})
+			if got[b] {
+				t.Fatalf("%v: %v x %v reported twice", x, rows, cols)
+			}
+			x.checkMaximal(t, b)
+			got[b] = true
+			return
+		})
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d maximal bicliques want %d", x, len(got), len(want))
+		}
+	}
+}
+
+func TestBiMaxAll(t *testing.T) {
+	opts := bimax.Options{MinRows: 2, MinCols: 2, Limit: 3}
+	for _, x := range randomMatrices(300) {
+		var want []int
+		for _, b := range bruteForce(x.n, x.m, x.data) {
+			if bits.OnesCount64(b.rows) >= opts.MinRows && bits.OnesCount64(b.cols) >= opts.MinCols {
+				want = append(want, b.area())
+			}
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+		if len(want) > opts.Limit {
+			want = want[:opts.Limit]
+		}
+		G, U, V := bimax.BinaryMatrixGraph(x.n, x.m, x.data)
+		got := bimax.BiMaxAll(G, U, V, opts)
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d bicliques want %d", x, len(got), len(want))
+		}
+		for i, r := range got {
+			if int(r.Weight) != want[i] || x.mask(r).area() != want[i] {
+				t.Fatalf("%v: biclique %d has area %v want %d", x, i, r.Weight, want[i])
+			}
+		}
+	}
+}
+
+func TestBiMaxAllBackend(t *testing.T) {
+	for _, x := range randomMatrices(100) {
+		G, U, V := bimax.BinaryMatrixGraph(x.n, x.m, x.data)
+		want := bimax.BiMaxAll(G, U, V, bimax.Options{})
+		got := bimax.BiMaxAll(G, U, V, bimax.Options{Backend: bimax.RoaringBackend})
+		if len(got) != len(want) {
+			t.Fatalf("%v: got %d bicliques want %d", x, len(got), len(want))
+		}
+		for i := range got {
+			if !got[i].Rows.IsEqual(want[i].Rows) || !got[i].Cols.IsEqual(want[i].Cols) {
+				t.Fatalf("%v: biclique %d is %v × %v want %v × %v", x, i,
+					got[i].Rows, got[i].Cols, want[i].Rows, want[i].Cols)
+			}
+		}
+	}
+}
+
+func TestBiMaxAllLimit(t *testing.T) {
+	for _, x := range randomMatrices(100) {
+		G, U, V := bimax.BinaryMatrixGraph(x.n, x.m, x.data)
+		all := bimax.BiMaxAll(G, U, V, bimax.Options{})
+		for limit := 1; limit <= 6; limit++ {
+			// The limited list is the start of the full one.
+			want := all[:min(limit, len(all))]
+			got := bimax.BiMaxAll(G, U, V, bimax.Options{Limit: limit})
+			if len(got) != len(want) {
+				t.Fatalf("%v: limit %d: got %d bicliques want %d", x, limit, len(got), len(want))
+			}
+			for i := range got {
+				if !got[i].Rows.IsEqual(want[i].Rows) || !got[i].Cols.IsEqual(want[i].Cols) {
+					t.Fatalf("%v: limit %d: biclique %d is %v × %v want %v × %v", x, limit, i,
+						got[i].Rows, got[i].Cols, want[i].Rows, want[i].Cols)
+				}
+			}
+		}
+	}
+}
+
+func TestBiMaxTies(t *testing.T) {
+	// {0, 1} x {0, 1} and {1, 2} x {1, 2} both have an area of 4.
+	data := []uint8{
+		1, 1, 0,
+		1, 1, 1,
+		0, 1, 1,
+	}
+	for i := 0; i < 20; i++ {
+		r := bimax.BiMaxBinaryMatrix(3, 3, data)
+		if !r.Rows.IsEqual(bimax.NewSetWith(0, 1)) || !r.Cols.IsEqual(bimax.NewSetWith(3, 4)) {
+			t.Fatalf("run %d: got %v x %v, want the first of the ties", i, r.Rows, r.Cols)
+		}
+	}
+}
@@ -1,77 +1,193 @@
 package main
 
+/*
+#include <stdlib.h>
+#include "bimax.h"
+
+// bimax_set_error takes ownership of the message of the last error on the
+// calling thread, which bimax_last_error returns.
+void bimax_set_error(char *msg);
+*/
+import "C"
+
 import (
-	"reflect"
+	"fmt"
+	"math"
 	"unsafe"
 
 	"github.com/maxsei/bimax"
-
-	"C"
 )
 
 func main() {}
 
+// status is the status code returned by an export with its error message.
+type status struct {
+	code C.int
+	msg  string
+}
+
+func (s *status) Error() string { return s.msg }
+
+func invalid(format string, args ...interface{}) *status {
+	return &status{C.BIMAX_ERR_INVALID, fmt.Sprintf(format, args...)}
+}
+
+// guard runs f at an export boundary.  Panics are recovered so that they never
+// unwind into C, and the message of any error can be read with
+// 'BiMaxLastError'.  Panics of the bimax package come from invalid input.
+func guard(f func() error) (code C.int) {
+	defer func() {
+		if r := recover(); r != nil {
+			code = C.BIMAX_ERR_INVALID
+			if _, ok := r.(string); !ok {
+				code = C.BIMAX_ERR_INTERNAL
+			}
+			C.bimax_set_error(C.CString(fmt.Sprint(r)))
+		}
+	}()
+	err := f()
+	if err == nil {
+		return C.BIMAX_OK
+	}
+	code = C.BIMAX_ERR_INTERNAL
+	if s, ok := err.(*status); ok {
+		code = s.code
+	}
+	C.bimax_set_error(C.CString(err.Error()))
+	return
+}
+
+// guardVoid is 'guard' for exports that return nothing, such as those that
+// release memory.  A recovered panic is only reported by 'BiMaxLastError'.
+func guardVoid(f func()) {
+	guard(func() error {
+		f()
+		return nil
+	})
+}
+
+//export BiMaxLastError
+func BiMaxLastError() *C.char {
+	// The message is owned by the library and valid until the next error on
+	// the calling thread.
+	return (*C.char)(C.bimax_last_error())
+}
+
 type BiMaxResult struct {
 	*bimax.BiMaxResult
 }
 
-// ToC converts the BiMaxResult to allocted data in C so for ease of use in
-// returning results functions 'BiMaxBinaryMatrixC' and 'BiMaxBinaryVerticesC', that
-// deal with returning to the C api.
-func (r *BiMaxResult) ToC() (C.size_t, *C.longlong, C.size_t, *C.longlong) {
-	// getSetValues64 returns the values of a set as []int64.
-	getSetValues64 := func(set *bimax.SetOp) []int64 {
-		result := make([]int64, 0, set.Card())
+// ToC copies the rows and columns of the result into arrays allocated in C
+// that are released by 'BiMaxFreeResult'.
+func (r *BiMaxResult) ToC(out *C.BiMaxResultC) {
+	// toArrC copies the values of a set into an array of C.longlong.
+	toArrC := func(set *bimax.SetOp) (C.size_t, *C.longlong) {
+		n := set.Card()
+		if n == 0 {
+			return 0, nil
+		}
+		ptr := (*C.longlong)(C.malloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.longlong(0)))))
+		arr := unsafe.Slice(ptr, n)
+		i := 0
 		set.Each(func(v int) (done bool) {
-			result = append(result, int64(v))
+			arr[i] = C.longlong(v)
+			i++
 			return
 		})
-		return result
-	}
-	// Get the values of row and column sets in the result as []int64.
-	rows := getSetValues64(r.Rows)
-	cols := getSetValues64(r.Cols)
-	// toArrC convert slice of int64to a pointer to allocated C.longlong memory
-	// and the size of length of the allocated memory measured in C.longlong.
-	toArrC := func(x []int64) (C.size_t, *C.longlong) {
-		bb := *(*[]byte)(unsafe.Pointer(&x))
-		bbH := (*reflect.SliceHeader)(unsafe.Pointer(&bb))
-		bbH.Len = len(x) * int(unsafe.Sizeof(x[0]))
-		return C.size_t(len(x)), (*C.longlong)(C.CBytes(bb))
+		return C.size_t(n), ptr
 	}
-	// Get the values of rows and cols as C memory pointers to C.longlong data and
-	// lengths of said pointers to data
-	lenRowsC, dataRowsC := toArrC(rows)
-	lenColsC, dataColsC := toArrC(cols)
-
-	return lenRowsC, dataRowsC, lenColsC, dataColsC
+	out.n_rows, out.rows = toArrC(r.Rows)
+	out.n_cols, out.cols = toArrC(r.Cols)
 }
 
+// BiMaxBinaryMatrixC finds the maximal biclique of the n by m binary matrix
+// data and stores it in result.  Columns are given as the vertices [n, n+m).
+//
 //export BiMaxBinaryMatrixC
-func BiMaxBinaryMatrixC(nC, mC C.longlong, dataC *C.char) (C.size_t, *C.longlong, C.size_t, *C.longlong) {
-	// Convert C input data into Go data
-	n := int(nC)
-	m := int(mC)
-
-	var data []uint8
-	dataH := (*reflect.SliceHeader)(unsafe.Pointer(&data))
-	dataH.Data = uintptr(unsafe.Pointer(dataC))
-	dataH.Len = n * m
-
-	result := bimax.BiMaxBinaryMatrix(n, m, data)
-	return (&BiMaxResult{result}).ToC()
+func BiMaxBinaryMatrixC(nC, mC C.longlong, dataC *C.char, result *C.BiMaxResultC) C.int {
+	return guard(func() error {
+		n, m := int(nC), int(mC)
+		if n <= 0 || m <= 0 {
+			return invalid("matrix [%d, %d] is empty", n, m)
+		}
+		if int64(n) != int64(nC) || int64(m) != int64(mC) || m > math.MaxInt/n {
+			return invalid("matrix [%d, %d] is too large", nC, mC)
+		}
+		if dataC == nil || result == nil {
+			return invalid("data and result must not be NULL")
+		}
+		data := unsafe.Slice((*uint8)(unsafe.Pointer(dataC)), n*m)
+		(&BiMaxResult{bimax.BiMaxBinaryMatrix(n, m, data)}).ToC(result)
+		return nil
+	})
 }
 
+// BiMaxVerticesC finds the maximal biclique of the bipartite graph whose
+// edges join uu[i] and vv[i] and stores it in result.
+//
 //export BiMaxVerticesC
-func BiMaxVerticesC(uuLenC C.size_t, uuC *C.longlong, vvLenC C.size_t, vvC *C.longlong) (C.size_t, *C.longlong, C.size_t, *C.longlong) {
-	var uu, vv []int
-	pointSliceToCData := func(length C.size_t, data *C.longlong, sl *[]int) {
-		header := (*reflect.SliceHeader)(unsafe.Pointer(sl))
-		header.Data = uintptr(unsafe.Pointer(data))
-		header.Len = int(length)
-	}
-	pointSliceToCData(uuLenC, uuC, &uu)
-	pointSliceToCData(vvLenC, vvC, &vv)
-	result := bimax.BiMaxVertices(uu, vv)
-	return (&BiMaxResult{result}).ToC()
+func BiMaxVerticesC(uuLenC C.size_t, uuC *C.longlong, vvLenC C.size_t, vvC *C.longlong, result *C.BiMaxResultC) C.int {
+	return guard(func() error {
+		if uuLenC != vvLenC {
+			return invalid("len(uu): %d len(vv): %d must be equal", uuLenC, vvLenC)
+		}
+		if uuLenC == 0 {
+			return invalid("empty edge list")
+		}
+		if uuC == nil || vvC == nil || result == nil {
+			return invalid("uu, vv and result must not be NULL")
+		}
+		toSlice := func(data *C.longlong) ([]int, error) {
+			vv := make([]int, uuLenC)
+			for i, v := range unsafe.Slice(data, uuLenC) {
+				var err error
+				if vv[i], err = vertex(C.int64_t(v)); err != nil {
+					return nil, err
+				}
+			}
+			return vv, nil
+		}
+		uu, err := toSlice(uuC)
+		if err != nil {
+			return err
+		}
+		vv, err := toSlice(vvC)
+		if err != nil {
+			return err
+		}
+		rows := bimax.NewSetFromSlice(uu)
+		for _, v := range vv {
+			if rows.Has(v) {
+				return invalid("vertex %d is in both uu and vv", v)
+			}
+		}
+		// The vertices are renumbered so that the graph is as large as the edge
+		// list.
+		dense, ids := compact(append(uu, vv...))
+		r := bimax.BiMaxVertices(dense[:len(uu)], dense[len(uu):])
+		for _, set := range []*bimax.SetOp{r.Rows, r.Cols} {
+			values := set.Values()
+			set.Remove(values...)
+			for _, v := range values {
+				set.Add(ids[v])
+			}
+		}
+		(&BiMaxResult{r}).ToC(result)
+		return nil
+	})
+}
+
+// BiMaxFreeResult releases the arrays of a result and resets it.  It is safe
+// to call on a result that was never filled or was already freed.
+//
+//export BiMaxFreeResult
+func BiMaxFreeResult(result *C.BiMaxResultC) {
+	guardVoid(func() {
+		if result == nil {
+			return
+		}
+		C.free(unsafe.Pointer(result.rows))
+		C.free(unsafe.Pointer(result.cols))
+		*result = C.BiMaxResultC{}
+	})
 }
@@ -0,0 +1,159 @@
+/*
+ * bimax.h - C interface of libbimax, built with `make shared`.
+ *
+ * Every function that can fail returns a status code, BIMAX_OK on success.
+ * On failure bimax_last_error returns a message describing the error, which
+ * is owned by the library and valid until the next error on the calling
+ * thread.  Objects returned by the library are released by the matching
+ * destroy or free function.  Functions without a status code, such as
+ * destroy given an object that was already destroyed, report errors only
+ * through bimax_last_error.
+ */
+#ifndef BIMAX_H
+#define BIMAX_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Status codes. */
+enum {
+	BIMAX_OK = 0,
+	/* The arguments were invalid. */
+	BIMAX_ERR_INVALID = 1,
+	/* The library failed unexpectedly. */
+	BIMAX_ERR_INTERNAL = 2,
+};
+
+/* Modes of bimax_run. */
+enum {
+	/* The biclique with the greatest value of the objective. */
+	BIMAX_MODE_LARGEST = 0,
+	/* The k bicliques with the greatest values of the objective. */
+	BIMAX_MODE_TOP_K = 1,
+	/* Every maximal biclique. */
+	BIMAX_MODE_ENUMERATE = 2,
+};
+
+/* Objectives scoring a biclique. */
+enum {
+	/* The number of rows times the number of columns. */
+	BIMAX_OBJECTIVE_AREA = 0,
+	/* The number of rows plus the number of columns. */
+	BIMAX_OBJECTIVE_VERTICES = 1,
+};
+
+/*
+ * bimax_options configures bimax_run.  A zeroed struct, or passing NULL,
+ * finds the largest biclique by area.
+ */
+typedef struct {
+	int mode;
+	int objective;
+	/* The fewest rows and columns of a biclique. */
+	int64_t min_rows;
+	int64_t min_cols;
+	/* The number of bicliques of BIMAX_MODE_TOP_K. */
+	int64_t k;
+} bimax_options;
+
+/*
+ * bimax_graph is a bipartite graph between rows and columns, each numbered
+ * from 0.  Only the rows and columns that have edges take up memory, so ids
+ * may be as large as INT64_MAX.
+ */
+typedef struct bimax_graph bimax_graph;
+
+/*
+ * bimax_result holds the bicliques found by bimax_run, ordered by
+ * non-increasing value of the objective.
+ */
+typedef struct bimax_result bimax_result;
+
+const char *bimax_last_error(void);
+
+/* bimax_graph_new stores a new empty graph in *graph. */
+int bimax_graph_new(bimax_graph **graph);
+/*
+ * bimax_graph_add_edge adds an edge between row u and column v.  Negative ids
+ * are rejected with BIMAX_ERR_INVALID.
+ */
+int bimax_graph_add_edge(bimax_graph *graph, int64_t u, int64_t v);
+/*
+ * bimax_graph_add_edges adds the n edges between row u[i] and column v[i].
+ * The ids are copied.
+ */
+int bimax_graph_add_edges(bimax_graph *graph, int64_t *u, int64_t *v, size_t n);
+/*
+ * bimax_graph_from_matrix stores in *graph a new graph of the rows by cols
+ * binary matrix whose entry (i, j) is the byte at data + i*row_stride +
+ * j*col_stride.  Strides are in bytes and may be negative, so a row major
+ * matrix has strides (cols, 1), a column major (Fortran order) matrix has
+ * strides (1, rows) and a numpy array passes its own strides.
+ */
+int bimax_graph_from_matrix(bimax_graph **graph, uint8_t *data, int64_t rows, int64_t cols, int64_t row_stride, int64_t col_stride);
+void bimax_graph_destroy(bimax_graph *graph);
+
+/* bimax_run finds the bicliques of graph and stores them in *result. */
+int bimax_run(bimax_graph *graph, bimax_options *options, bimax_result **result);
+size_t bimax_result_count(bimax_result *result);
+/*
+ * bimax_result_rows and bimax_result_cols store the rows or columns of the
+ * i'th biclique of result in *values and their number in *n.  The array is
+ * owned by result.
+ */
+int bimax_result_rows(bimax_result *result, size_t i, int64_t **values, size_t *n);
+int bimax_result_cols(bimax_result *result, size_t i, int64_t **values, size_t *n);
+/* bimax_result_weight stores the value of the objective of the i'th biclique. */
+int bimax_result_weight(bimax_result *result, size_t i, double *weight);
+void bimax_result_destroy(bimax_result *result);
+
+/*
+ * bimax_callback is called by bimax_enumerate with the rows and columns of a
+ * maximal biclique, sorted in increasing order, and the user data given to
+ * bimax_enumerate.  The arrays are only valid until the callback returns.  A
+ * non-zero return value stops the enumeration.
+ */
+typedef int (*bimax_callback)(int64_t *rows, size_t n_rows, int64_t *cols, size_t n_cols, void *user_data);
+
+/*
+ * bimax_enumerate calls callback once for each maximal biclique of graph as it
+ * is found, without collecting them.  Only the fewest rows and columns of
+ * options are used.  Stopping the enumeration from the callback is not an
+ * error.
+ */
+int bimax_enumerate(bimax_graph *graph, bimax_options *options, bimax_callback callback, void *user_data);
+
+/*
+ * BiMaxResultC holds the rows and columns of the biclique found by
+ * BiMaxBinaryMatrixC or BiMaxVerticesC.  The arrays are allocated by the
+ * library and released with BiMaxFreeResult.
+ */
+typedef struct {
+	size_t n_rows;
+	long long *rows;
+	size_t n_cols;
+	long long *cols;
+} BiMaxResultC;
+
+char *BiMaxLastError(void);
+/*
+ * BiMaxBinaryMatrixC finds the largest biclique of the n by m binary matrix
+ * data.  Columns are given as the vertices [n, n+m).
+ */
+int BiMaxBinaryMatrixC(long long n, long long m, char *data, BiMaxResultC *result);
+/*
+ * BiMaxVerticesC finds the largest biclique of the bipartite graph whose edges
+ * join uu[i] and vv[i].
+ */
+int BiMaxVerticesC(size_t uu_len, long long *uu, size_t vv_len, long long *vv, BiMaxResultC *result);
+void BiMaxFreeResult(BiMaxResultC *result);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,7 @@
+#include "bimax.h"
+
+// bimax_call calls a callback on behalf of Go, which cannot call C function
+// pointers.
+int bimax_call(bimax_callback callback, int64_t *rows, size_t n_rows, int64_t *cols, size_t n_cols, void *user_data) {
+	return callback(rows, n_rows, cols, n_cols, user_data);
+}
@@ -0,0 +1,78 @@
+package main
+
+/*
+#include <stdlib.h>
+#include "bimax.h"
+
+int bimax_call(bimax_callback callback, int64_t *rows, size_t n_rows, int64_t *cols, size_t n_cols, void *user_data);
+*/
+import "C"
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/maxsei/bimax"
+)
+
+// buffer is an array in C memory that is grown as needed and reused between
+// calls of a callback.  Only C memory is passed to callbacks so that they may
+// keep no Go pointers and may call back into the library.
+type buffer struct {
+	ptr *C.int64_t
+	cap int
+}
+
+// fill copies the sorted ids of the values of set into b.
+func (b *buffer) fill(set *bimax.UnorderedSet, ids []int) (*C.int64_t, C.size_t) {
+	values := set.Values()
+	sort.Ints(values)
+	if len(values) > b.cap {
+		C.free(unsafe.Pointer(b.ptr))
+		b.cap = 2 * len(values)
+		b.ptr = (*C.int64_t)(C.malloc(C.size_t(b.cap) * C.size_t(unsafe.Sizeof(C.int64_t(0)))))
+	}
+	if len(values) == 0 {
+		return b.ptr, 0
+	}
+	arr := unsafe.Slice(b.ptr, len(values))
+	for i, v := range values {
+		arr[i] = C.int64_t(ids[v])
+	}
+	return b.ptr, C.size_t(len(values))
+}
+
+func (b *buffer) free() { C.free(unsafe.Pointer(b.ptr)) }
+
+//export bimax_enumerate
+func bimax_enumerate(g *C.bimax_graph, o *C.bimax_options, callback C.bimax_callback, userData unsafe.Pointer) C.int {
+	return guard(func() error {
+		x, err := graphOf(g)
+		if err != nil {
+			return err
+		}
+		if callback == nil {
+			return invalid("callback must not be NULL")
+		}
+		opts, err := options(o)
+		if err != nil {
+			return err
+		}
+		if len(x.uu) == 0 {
+			return nil
+		}
+		var rowBuf, colBuf buffer
+		defer rowBuf.free()
+		defer colBuf.free()
+		G, U, V, ids := x.build()
+		bimax.Enumerate(G, U, V, func(rows, cols *bimax.UnorderedSet) (done bool) {
+			if rows.Card() < opts.MinRows || cols.Card() < opts.MinCols {
+				return
+			}
+			r, nr := rowBuf.fill(rows, ids)
+			c, nc := colBuf.fill(cols, ids)
+			return C.bimax_call(callback, r, nr, c, nc, userData) != 0
+		})
+		return nil
+	})
+}
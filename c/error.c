@@ -0,0 +1,14 @@
+#include <stdlib.h>
+#include "bimax.h"
+
+// The message of the last error on each thread.
+static __thread char *last_error;
+
+void bimax_set_error(char *msg) {
+	free(last_error);
+	last_error = msg;
+}
+
+const char *bimax_last_error(void) {
+	return last_error;
+}
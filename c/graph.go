@@ -0,0 +1,369 @@
+package main
+
+/*
+#include <stdlib.h>
+#include "bimax.h"
+
+struct bimax_graph {
+	uintptr_t handle;
+};
+
+typedef struct {
+	size_t n_rows;
+	int64_t *rows;
+	size_t n_cols;
+	int64_t *cols;
+	double weight;
+} bimax_biclique;
+
+struct bimax_result {
+	size_t count;
+	bimax_biclique *bicliques;
+};
+*/
+import "C"
+
+import (
+	"math"
+	"runtime/cgo"
+	"sort"
+	"unsafe"
+
+	"github.com/maxsei/bimax"
+	graphpkg "github.com/yourbasic/graph"
+)
+
+// graph is the edge list behind a bimax_graph.  Rows and columns are numbered
+// separately from 0.
+type graph struct {
+	uu, vv []int
+}
+
+func graphOf(g *C.bimax_graph) (*graph, error) {
+	if g == nil {
+		return nil, invalid("graph must not be NULL")
+	}
+	return cgo.Handle(g.handle).Value().(*graph), nil
+}
+
+// newGraph returns a bimax_graph holding a handle to x.
+func newGraph(x *graph) *C.bimax_graph {
+	g := (*C.bimax_graph)(C.calloc(1, C.size_t(unsafe.Sizeof(C.bimax_graph{}))))
+	g.handle = C.uintptr_t(cgo.NewHandle(x))
+	return g
+}
+
+//export bimax_graph_new
+func bimax_graph_new(out **C.bimax_graph) C.int {
+	return guard(func() error {
+		if out == nil {
+			return invalid("graph must not be NULL")
+		}
+		*out = newGraph(&graph{})
+		return nil
+	})
+}
+
+// vertex converts the id of a vertex from C.
+func vertex(v C.int64_t) (int, error) {
+	if v < 0 || int64(int(v)) != int64(v) {
+		return 0, invalid("vertex %d is out of range [0, %d]", v, math.MaxInt)
+	}
+	return int(v), nil
+}
+
+// addEdge adds the edge between row u and column v.
+func (x *graph) addEdge(u, v C.int64_t) error {
+	uu, err := vertex(u)
+	if err != nil {
+		return err
+	}
+	vv, err := vertex(v)
+	if err != nil {
+		return err
+	}
+	x.uu, x.vv = append(x.uu, uu), append(x.vv, vv)
+	return nil
+}
+
+//export bimax_graph_add_edge
+func bimax_graph_add_edge(g *C.bimax_graph, u, v C.int64_t) C.int {
+	return guard(func() error {
+		x, err := graphOf(g)
+		if err != nil {
+			return err
+		}
+		return x.addEdge(u, v)
+	})
+}
+
+//export bimax_graph_add_edges
+func bimax_graph_add_edges(g *C.bimax_graph, u, v *C.int64_t, n C.size_t) C.int {
+	return guard(func() error {
+		x, err := graphOf(g)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if u == nil || v == nil {
+			return invalid("u and v must not be NULL")
+		}
+		uu, vv := unsafe.Slice(u, n), unsafe.Slice(v, n)
+		// The edges are added all or none.
+		k := len(x.uu)
+		for i := range uu {
+			if err := x.addEdge(uu[i], vv[i]); err != nil {
+				x.uu, x.vv = x.uu[:k], x.vv[:k]
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+//export bimax_graph_from_matrix
+func bimax_graph_from_matrix(out **C.bimax_graph, data *C.uint8_t, rows, cols, rowStride, colStride C.int64_t) C.int {
+	return guard(func() error {
+		if out == nil {
+			return invalid("graph must not be NULL")
+		}
+		if rows <= 0 || cols <= 0 {
+			return invalid("matrix [%d, %d] is empty", rows, cols)
+		}
+		if data == nil {
+			return invalid("data must not be NULL")
+		}
+		n, m := int(rows), int(cols)
+		if int64(n) != int64(rows) || int64(m) != int64(cols) {
+			return invalid("matrix [%d, %d] is too large", rows, cols)
+		}
+		x := &graph{}
+		for i := 0; i < n; i++ {
+			for j := 0; j < m; j++ {
+				offset := int64(i)*int64(rowStride) + int64(j)*int64(colStride)
+				switch *(*uint8)(unsafe.Add(unsafe.Pointer(data), offset)) {
+				case 0:
+				case 1:
+					x.uu, x.vv = append(x.uu, i), append(x.vv, j)
+				default:
+					return invalid("entry (%d, %d) is not a zero or 1", i, j)
+				}
+			}
+		}
+		*out = newGraph(x)
+		return nil
+	})
+}
+
+//export bimax_graph_destroy
+func bimax_graph_destroy(g *C.bimax_graph) {
+	guardVoid(func() {
+		if g == nil {
+			return
+		}
+		// Deleting a handle that was already deleted panics, leaving g to
+		// the caller.
+		cgo.Handle(g.handle).Delete()
+		C.free(unsafe.Pointer(g))
+	})
+}
+
+// options converts bimax_options to the options of 'bimax.BiMaxAll'.
+func options(o *C.bimax_options) (opts bimax.Options, err error) {
+	if o == nil {
+		return bimax.Options{Limit: 1}, nil
+	}
+	switch o.objective {
+	case C.BIMAX_OBJECTIVE_AREA:
+	case C.BIMAX_OBJECTIVE_VERTICES:
+		opts.Objective = bimax.VertexWeight(func(int) float64 { return 1 })
+	default:
+		return opts, invalid("unknown objective %d", o.objective)
+	}
+	switch o.mode {
+	case C.BIMAX_MODE_LARGEST:
+		opts.Limit = 1
+	case C.BIMAX_MODE_TOP_K:
+		if o.k <= 0 {
+			return opts, invalid("BIMAX_MODE_TOP_K needs k greater than 0")
+		}
+		opts.Limit = int(o.k)
+	case C.BIMAX_MODE_ENUMERATE:
+	default:
+		return opts, invalid("unknown mode %d", o.mode)
+	}
+	opts.MinRows, opts.MinCols = int(o.min_rows), int(o.min_cols)
+	return
+}
+
+// compact renumbers the distinct values of vv from 0 in increasing order.  It
+// returns the new values along with the original value of each number, so
+// that a graph is as large as its edge list however large its vertices are.
+func compact(vv []int) (dense, original []int) {
+	original = append([]int(nil), vv...)
+	sort.Ints(original)
+	k := 0
+	for i, v := range original {
+		if i == 0 || v != original[k-1] {
+			original[k] = v
+			k++
+		}
+	}
+	original = original[:k]
+	dense = make([]int, len(vv))
+	for i, v := range vv {
+		dense[i] = sort.SearchInts(original, v)
+	}
+	return
+}
+
+// build returns the graph of x with the rows and columns that have edges
+// renumbered as the vertices [0, rows) and [rows, rows+cols).  ids holds the
+// row or column of each vertex.
+func (x *graph) build() (G *graphpkg.Mutable, U, V *bimax.UnorderedSet, ids []int) {
+	uu, rowIDs := compact(x.uu)
+	vv, colIDs := compact(x.vv)
+	for i := range vv {
+		vv[i] += len(rowIDs)
+	}
+	G, U, V = bimax.VerticesGraph(uu, vv)
+	return G, U, V, append(rowIDs, colIDs...)
+}
+
+//export bimax_run
+func bimax_run(g *C.bimax_graph, o *C.bimax_options, out **C.bimax_result) C.int {
+	return guard(func() error {
+		x, err := graphOf(g)
+		if err != nil {
+			return err
+		}
+		if out == nil {
+			return invalid("result must not be NULL")
+		}
+		opts, err := options(o)
+		if err != nil {
+			return err
+		}
+		if len(x.uu) == 0 {
+			*out = newResult(nil, nil)
+			return nil
+		}
+		G, U, V, ids := x.build()
+		*out = newResult(bimax.BiMaxAll(G, U, V, opts), ids)
+		return nil
+	})
+}
+
+// newResult copies results into C memory, converting each vertex v to ids[v].
+func newResult(results []*bimax.BiMaxResult, ids []int) *C.bimax_result {
+	r := (*C.bimax_result)(C.calloc(1, C.size_t(unsafe.Sizeof(C.bimax_result{}))))
+	if len(results) == 0 {
+		return r
+	}
+	r.count = C.size_t(len(results))
+	r.bicliques = (*C.bimax_biclique)(C.calloc(r.count, C.size_t(unsafe.Sizeof(C.bimax_biclique{}))))
+	// toArrC copies the sorted ids of the values of a set into an array of
+	// C.int64_t.
+	toArrC := func(set *bimax.SetOp) (C.size_t, *C.int64_t) {
+		values := set.Values()
+		if len(values) == 0 {
+			return 0, nil
+		}
+		sort.Ints(values)
+		ptr := (*C.int64_t)(C.malloc(C.size_t(len(values)) * C.size_t(unsafe.Sizeof(C.int64_t(0)))))
+		for i, v := range values {
+			unsafe.Slice(ptr, len(values))[i] = C.int64_t(ids[v])
+		}
+		return C.size_t(len(values)), ptr
+	}
+	for i, b := range results {
+		dst := &unsafe.Slice(r.bicliques, len(results))[i]
+		dst.n_rows, dst.rows = toArrC(b.Rows)
+		dst.n_cols, dst.cols = toArrC(b.Cols)
+		dst.weight = C.double(b.Weight)
+	}
+	return r
+}
+
+// biclique returns the i'th biclique of r.
+func biclique(r *C.bimax_result, i C.size_t) (*C.bimax_biclique, error) {
+	if r == nil {
+		return nil, invalid("result must not be NULL")
+	}
+	if i >= r.count {
+		return nil, invalid("biclique %d is out of range [0, %d)", i, r.count)
+	}
+	return &unsafe.Slice(r.bicliques, r.count)[i], nil
+}
+
+//export bimax_result_count
+func bimax_result_count(r *C.bimax_result) (count C.size_t) {
+	guardVoid(func() {
+		if r != nil {
+			count = r.count
+		}
+	})
+	return
+}
+
+//export bimax_result_rows
+func bimax_result_rows(r *C.bimax_result, i C.size_t, values **C.int64_t, n *C.size_t) C.int {
+	return guard(func() error {
+		b, err := biclique(r, i)
+		if err != nil {
+			return err
+		}
+		if values == nil || n == nil {
+			return invalid("values and n must not be NULL")
+		}
+		*values, *n = b.rows, b.n_rows
+		return nil
+	})
+}
+
+//export bimax_result_cols
+func bimax_result_cols(r *C.bimax_result, i C.size_t, values **C.int64_t, n *C.size_t) C.int {
+	return guard(func() error {
+		b, err := biclique(r, i)
+		if err != nil {
+			return err
+		}
+		if values == nil || n == nil {
+			return invalid("values and n must not be NULL")
+		}
+		*values, *n = b.cols, b.n_cols
+		return nil
+	})
+}
+
+//export bimax_result_weight
+func bimax_result_weight(r *C.bimax_result, i C.size_t, weight *C.double) C.int {
+	return guard(func() error {
+		b, err := biclique(r, i)
+		if err != nil {
+			return err
+		}
+		if weight == nil {
+			return invalid("weight must not be NULL")
+		}
+		*weight = b.weight
+		return nil
+	})
+}
+
+//export bimax_result_destroy
+func bimax_result_destroy(r *C.bimax_result) {
+	guardVoid(func() {
+		if r == nil {
+			return
+		}
+		for _, b := range unsafe.Slice(r.bicliques, r.count) {
+			C.free(unsafe.Pointer(b.rows))
+			C.free(unsafe.Pointer(b.cols))
+		}
+		C.free(unsafe.Pointer(r.bicliques))
+		C.free(unsafe.Pointer(r))
+	})
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/table"
+	"github.com/yourbasic/graph"
+)
+
+// input is a bipartite graph loaded from one of the input formats.
+type input struct {
+	G    *graph.Mutable
+	U, V *bimax.UnorderedSet
+	// n and m are the number of rows and columns of a matrix, whose columns
+	// are the vertices [n, n+m).  The columns of an edge list are numbered
+	// apart from its rows and shifted by n, while m is zero.
+	n, m int
+	// rowIDs and colIDs are the vertices of an edge list in the order they
+	// are numbered from 0, so that the graph only has vertices with edges.
+	rowIDs, colIDs []int
+	// pattern holds the 1's and 0's of a matrix and weights its entries.
+	pattern []uint8
+	weights []float64
+	// rowLabels and colLabels label a csv or tsv matrix.
+	rowLabels, colLabels []string
+}
+
+// load reads an input of the given format.  Matrices must hold 0's and 1's
+// unless weighted is set, when they may hold any non-negative weights.
+func load(r io.Reader, format string, weighted bool) (*input, error) {
+	x := &input{}
+	switch format {
+	case "matrix":
+		n, m, data, err := readMatrix(r)
+		if err != nil {
+			return nil, err
+		}
+		x.n, x.m, x.weights = n, m, data
+	case "csv", "tsv":
+		opts := table.Options{}
+		if format == "tsv" {
+			opts.Comma = '\t'
+		}
+		t, err := table.Read(r, opts)
+		if err != nil {
+			return nil, err
+		}
+		x.n, x.m, x.weights = t.N, t.M, t.Data
+		x.rowLabels, x.colLabels = t.RowLabels, t.ColLabels
+	case "edges":
+		uu, vv, err := readEdges(r)
+		if err != nil {
+			return nil, err
+		}
+		uu, x.rowIDs = compact(uu)
+		vv, x.colIDs = compact(vv)
+		x.G, x.U, x.V, x.n = bimax.EdgeListGraph(uu, vv)
+		return x, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+
+	x.pattern = make([]uint8, len(x.weights))
+	for i, w := range x.weights {
+		// Missing values of a table are read as 0.
+		if w != w {
+			w, x.weights[i] = 0, 0
+		}
+		if w != 0 && w != 1 && !weighted {
+			return nil, fmt.Errorf("%v is not a zero or 1, use -objective weight for weighted matrices", w)
+		}
+		if w < 0 {
+			return nil, fmt.Errorf("%v is not a non-negative weight", w)
+		}
+		if w != 0 {
+			x.pattern[i] = 1
+		}
+	}
+	if x.n == 0 || x.m == 0 {
+		return nil, fmt.Errorf("empty matrix")
+	}
+	x.G, x.U, x.V = bimax.BinaryMatrixGraph(x.n, x.m, x.pattern)
+	return x, nil
+}
+
+// row returns the row i of a matrix or the vertex numbered i of an edge list.
+func (x *input) row(i int) int {
+	if x.rowIDs != nil {
+		return x.rowIDs[i]
+	}
+	return i
+}
+
+// col returns the column j of a matrix or the vertex numbered j of an edge
+// list.
+func (x *input) col(j int) int {
+	if x.colIDs != nil {
+		return x.colIDs[j]
+	}
+	return j
+}
+
+// compact renumbers the distinct values of vv from 0 in increasing order.  It
+// returns the new values along with the original value of each number.
+func compact(vv []int) (dense, original []int) {
+	original = append([]int(nil), vv...)
+	sort.Ints(original)
+	k := 0
+	for i, v := range original {
+		if i == 0 || v != original[k-1] {
+			original[k] = v
+			k++
+		}
+	}
+	original = original[:k]
+	dense = make([]int, len(vv))
+	for i, v := range vv {
+		dense[i] = sort.SearchInts(original, v)
+	}
+	return
+}
+
+// readMatrix reads a dense matrix with one row per line and the entries of a
+// row separated by white space or commas.  Blank lines and lines starting with
+// '#' are skipped.
+func readMatrix(r io.Reader) (n, m int, data []float64, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<30)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := splitFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if n == 0 {
+			m = len(fields)
+		} else if len(fields) != m {
+			return 0, 0, nil, fmt.Errorf("line %d: %d entries in a matrix of %d columns", line, len(fields), m)
+		}
+		for _, field := range fields {
+			x, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return 0, 0, nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			data = append(data, x)
+		}
+		n++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, nil, err
+	}
+	if n == 0 {
+		return 0, 0, nil, fmt.Errorf("empty matrix")
+	}
+	return n, m, data, nil
+}
+
+// readEdges reads an edge list with one edge per line given as the two
+// vertices u ∈ U and v ∈ V separated by white space or a comma.  The vertices
+// of U and V are numbered independently.  Blank lines and lines starting with
+// '#' are skipped.
+func readEdges(r io.Reader) (uu, vv []int, err error) {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := splitFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, nil, fmt.Errorf("line %d: an edge needs 2 vertices not %d", line, len(fields))
+		}
+		u, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		v, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if u < 0 || v < 0 {
+			return nil, nil, fmt.Errorf("line %d: vertices must not be negative", line)
+		}
+		uu, vv = append(uu, u), append(vv, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(uu) == 0 {
+		return nil, nil, fmt.Errorf("empty edge list")
+	}
+	return uu, vv, nil
+}
+
+// splitFields splits a line on white space and commas, ignoring comments.
+func splitFields(line string) []string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil
+	}
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
@@ -0,0 +1,163 @@
+// Command bimax finds the maximal bicliques of a binary matrix or of the
+// bipartite graph of an edge list.
+//
+// Usage:
+//
+//	bimax [flags] [file]
+//	bimax render [flags] [file]
+//	bimax serve [flags]
+//
+// The input is read from file, or from standard input when file is omitted or
+// is "-".  Rows and columns of a matrix are numbered from 0 in the output and
+// the vertices of an edge list are printed as they were given.  The first
+// vertex of each edge is a row and the second a column, and rows and columns
+// are numbered independently.  The render subcommand draws a matrix as a
+// heatmap with its bicliques outlined and the serve subcommand serves the HTTP
+// API of package server.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/maxsei/bimax"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "bimax:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "render":
+			return runRender(args[1:], stdin)
+		case "serve":
+			return runServe(args[1:])
+		}
+	}
+	return runFind(args, stdin, stdout)
+}
+
+// searchFlags are the flags shared by the subcommands that search for
+// bicliques.
+type searchFlags struct {
+	format, objective       string
+	minRows, minCols, limit int
+}
+
+func (s *searchFlags) register(flags *flag.FlagSet, formats string) {
+	flags.StringVar(&s.format, "format", "matrix", "input `format`: "+formats)
+	flags.StringVar(&s.objective, "objective", "area", "`objective` to maximize: area, vertices or weight (matrix entries as edge weights)")
+	flags.IntVar(&s.minRows, "min-rows", 1, "fewest rows in a biclique")
+	flags.IntVar(&s.minCols, "min-cols", 1, "fewest columns in a biclique")
+	flags.IntVar(&s.limit, "k", 1, "number of bicliques to report, 0 for all")
+}
+
+// search loads the input named by the only argument of flags and finds its
+// bicliques.  Columns are given as column indices, or as the vertices of an
+// edge list.
+func (s *searchFlags) search(flags *flag.FlagSet, stdin io.Reader) (*input, []*bimax.BiMaxResult, error) {
+	if flags.NArg() > 1 {
+		return nil, nil, fmt.Errorf("too many arguments")
+	}
+	in := stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	x, err := load(in, s.format, s.objective == "weight")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := bimax.Options{MinRows: s.minRows, MinCols: s.minCols, Limit: s.limit}
+	switch s.objective {
+	case "area":
+	case "weight":
+		if x.weights == nil {
+			return nil, nil, fmt.Errorf("objective weight needs a matrix")
+		}
+		opts.Objective = bimax.EdgeWeight(func(u, v int) float64 { return x.weights[u*x.m+v-x.n] })
+	case "vertices":
+		opts.Objective = bimax.VertexWeight(func(int) float64 { return 1 })
+	default:
+		return nil, nil, fmt.Errorf("unknown objective %q", s.objective)
+	}
+
+	results := bimax.BiMaxAll(x.G, x.U, x.V, opts)
+	// Rows and columns are given as indices or as they were in the edge list.
+	for _, r := range results {
+		rows, cols := bimax.NewSet(), bimax.NewSet()
+		r.Rows.Each(func(u int) (_ bool) {
+			rows.Add(x.row(u))
+			return
+		})
+		r.Cols.Each(func(v int) (_ bool) {
+			cols.Add(x.col(v - x.n))
+			return
+		})
+		r.Rows, r.Cols = rows.SetOp, cols.SetOp
+	}
+	return x, results, nil
+}
+
+func runFind(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("bimax", flag.ContinueOnError)
+	var s searchFlags
+	s.register(flags, "matrix, csv, tsv or edges")
+	output := flags.String("output", "text", "output `format`: text, json, csv or tsv")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	switch *output {
+	case "text", "json", "csv", "tsv":
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+	_, results, err := s.search(flags, stdin)
+	if err != nil {
+		return err
+	}
+	switch *output {
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case "csv":
+		return bimax.WriteCSV(stdout, results)
+	case "tsv":
+		return bimax.WriteTSV(stdout, results)
+	}
+	return writeText(stdout, results)
+}
+
+func writeText(w io.Writer, results []*bimax.BiMaxResult) error {
+	for i, r := range results {
+		_, err := fmt.Fprintf(w, "# %d: %d rows x %d cols, weight %v\nrows: %s\ncols: %s\n",
+			i+1, r.Rows.Card(), r.Cols.Card(), r.Weight, join(r.Rows), join(r.Cols))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// join returns the values of a set in increasing order separated by spaces.
+func join(set *bimax.SetOp) string {
+	vv := set.Values()
+	sort.Ints(vv)
+	s := fmt.Sprint(vv)
+	return s[1 : len(s)-1]
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		args  []string
+		input string
+		want  string
+	}{
+		{
+			"matrix", nil,
+			"1 1 0\n1 1 0\n0 0 1\n",
+			"# 1: 2 rows x 2 cols, weight 4\nrows: 0 1\ncols: 0 1\n",
+		},
+		{
+			"edges", []string{"-format", "edges"},
+			"10 1\n10 2\n",
+			"# 1: 1 rows x 2 cols, weight 2\nrows: 10\ncols: 1 2\n",
+		},
+		{
+			// Rows and columns of an edge list are numbered independently.
+			"edges sharing ids", []string{"-format", "edges"},
+			"0 0\n0 1\n1 0\n1 1\n",
+			"# 1: 2 rows x 2 cols, weight 4\nrows: 0 1\ncols: 0 1\n",
+		},
+		{
+			// Vertices are renumbered so that large ids take no memory.
+			"large ids", []string{"-format", "edges"},
+			"2000000000 3000000000\n2000000000 7\n",
+			"# 1: 1 rows x 2 cols, weight 2\nrows: 2000000000\ncols: 7 3000000000\n",
+		},
+		{
+			"tsv output", []string{"-format", "edges", "-output", "tsv", "-k", "0"},
+			"# comment\n0 5\n1,6\n",
+			"id\trows\tcols\tn_rows\tn_cols\tweight\tp_value\n1\t0\t5\t1\t1\t1\t0\n2\t1\t6\t1\t1\t1\t0\n",
+		},
+	} {
+		var out bytes.Buffer
+		if err := run(tt.args, strings.NewReader(tt.input), &out); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, out.String(), tt.want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		args  []string
+		input string
+	}{
+		{"not binary", nil, "1 2\n0 1\n"},
+		{"ragged", nil, "1 1\n0\n"},
+		{"empty", nil, "# nothing\n"},
+		{"negative vertex", []string{"-format", "edges"}, "-1 2\n"},
+		{"three vertices", []string{"-format", "edges"}, "1 2 3\n"},
+		{"weight of edges", []string{"-format", "edges", "-objective", "weight"}, "0 1\n"},
+		{"unknown format", []string{"-format", "xml"}, "1\n"},
+	} {
+		var out bytes.Buffer
+		if err := run(tt.args, strings.NewReader(tt.input), &out); err == nil {
+			t.Errorf("%s: got no error and output %q", tt.name, out.String())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/render"
+)
+
+func runRender(args []string, stdin io.Reader) error {
+	flags := flag.NewFlagSet("bimax render", flag.ContinueOnError)
+	var s searchFlags
+	s.register(flags, "matrix, csv or tsv")
+	out := flags.String("o", "bimax.png", "output `file`, drawn as SVG if it ends in .svg and as PNG otherwise")
+	cell := flags.Int("cell", 0, "size of a cell in pixels, 0 to fit the image")
+	maxSize := flags.Int("max-size", 1000, "greatest width and height of the heatmap before it is downsampled")
+	labels := flags.Bool("labels", true, "label the axes with the row and column labels of csv or tsv input")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	x, results, err := s.search(flags, stdin)
+	if err != nil {
+		return err
+	}
+	if x.pattern == nil {
+		return fmt.Errorf("render needs a matrix")
+	}
+	// The renderer expects the columns of results to follow the rows.
+	for _, r := range results {
+		cols := bimax.NewSet()
+		r.Cols.Each(func(j int) (_ bool) {
+			cols.Add(j + x.n)
+			return
+		})
+		r.Cols = cols.SetOp
+	}
+	opts := render.Options{Cell: *cell, MaxWidth: *maxSize, MaxHeight: *maxSize}
+	if *labels {
+		opts.RowLabels, opts.ColLabels = x.rowLabels, x.colLabels
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	draw := render.PNG
+	if strings.EqualFold(filepath.Ext(*out), ".svg") {
+		draw = render.SVG
+	}
+	if err := draw(f, x.n, x.m, x.pattern, results, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
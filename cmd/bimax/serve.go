@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/maxsei/bimax/server"
+)
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("bimax serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "`address` to listen on")
+	var config server.Config
+	flags.Int64Var(&config.MaxBodyBytes, "max-bytes", 32<<20, "largest request body in bytes")
+	flags.IntVar(&config.MaxCells, "max-cells", 1e7, "largest number of cells of a matrix")
+	flags.IntVar(&config.MaxEdges, "max-edges", 1e7, "largest number of edges of a graph")
+	flags.DurationVar(&config.Timeout, "timeout", server.DefaultTimeout, "longest time spent on a problem")
+	flags.IntVar(&config.MaxJobs, "max-jobs", 4, "largest number of problems solved at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("too many arguments")
+	}
+	// Slow clients may not hold connections open for longer than it takes to
+	// send a request of the largest size.
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(config),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}
+	return srv.ListenAndServe()
+}
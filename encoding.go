@@ -0,0 +1,161 @@
+package bimax
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sortedValues returns the values of a set in increasing order.
+func sortedValues(s *SetOp) []int { return s.Sorted() }
+
+// biMaxResultJSON is the JSON encoding of a BiMaxResult.
+type biMaxResultJSON struct {
+	Rows   []int   `json:"rows"`
+	Cols   []int   `json:"cols"`
+	NRows  int     `json:"n_rows"`
+	NCols  int     `json:"n_cols"`
+	Weight float64 `json:"weight"`
+	PValue float64 `json:"p_value,omitempty"`
+}
+
+// MarshalJSON encodes the result as an object of its rows and columns in
+// increasing order along with their sizes, weight and p-value.
+func (r *BiMaxResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(biMaxResultJSON{
+		Rows:   sortedValues(r.Rows),
+		Cols:   sortedValues(r.Cols),
+		NRows:  r.Rows.Card(),
+		NCols:  r.Cols.Card(),
+		Weight: r.Weight,
+		PValue: r.PValue,
+	})
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON.  The sizes are taken
+// from the rows and columns.
+func (r *BiMaxResult) UnmarshalJSON(b []byte) error {
+	var v biMaxResultJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	r.Rows = NewSetWith(v.Rows...).SetOp
+	r.Cols = NewSetWith(v.Cols...).SetOp
+	r.Weight, r.PValue = v.Weight, v.PValue
+	return nil
+}
+
+// lessResult orders results by decreasing weight, then decreasing number of
+// rows and then by their rows and columns in increasing order.
+func lessResult(a, b *BiMaxResult) bool {
+	return (&rankedResult{BiMaxResult: a}).less(&rankedResult{BiMaxResult: b})
+}
+
+// rankedResult is a result along with its rows and columns in increasing
+// order, which are only sorted once they are needed to break a tie so that a
+// result compared many times is sorted once.
+type rankedResult struct {
+	*BiMaxResult
+	rows, cols []int
+}
+
+func (r *rankedResult) sorted() (rows, cols []int) {
+	if r.rows == nil {
+		r.rows, r.cols = sortedValues(r.Rows), sortedValues(r.Cols)
+	}
+	return r.rows, r.cols
+}
+
+// less orders results as 'lessResult'.
+func (r *rankedResult) less(other *rankedResult) bool {
+	if r.Weight != other.Weight {
+		return r.Weight > other.Weight
+	}
+	if r.Rows.Card() != other.Rows.Card() {
+		return r.Rows.Card() > other.Rows.Card()
+	}
+	rows, cols := r.sorted()
+	otherRows, otherCols := other.sorted()
+	if c := compareInts(rows, otherRows); c != 0 {
+		return c < 0
+	}
+	return compareInts(cols, otherCols) < 0
+}
+
+func compareInts(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+// SortResults sorts results by decreasing weight, breaking ties by size and
+// then by their rows and columns, so that the order is the same across runs.
+func SortResults(results []*BiMaxResult) {
+	ranked := make([]*rankedResult, len(results))
+	for i, r := range results {
+		ranked[i] = &rankedResult{BiMaxResult: r}
+	}
+	sortRanked(ranked)
+	for i, r := range ranked {
+		results[i] = r.BiMaxResult
+	}
+}
+
+func sortRanked(ranked []*rankedResult) {
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].less(ranked[j]) })
+}
+
+// WriteCSV writes results as comma separated values with one row per
+// bicluster, see 'WriteDelimited'.
+func WriteCSV(w io.Writer, results []*BiMaxResult) error { return WriteDelimited(w, results, ',') }
+
+// WriteTSV writes results as tab separated values with one row per bicluster,
+// see 'WriteDelimited'.
+func WriteTSV(w io.Writer, results []*BiMaxResult) error { return WriteDelimited(w, results, '\t') }
+
+// WriteDelimited writes results with one row per bicluster after a header
+// row.  The fields of a row are the index of the bicluster counted from 1, its
+// rows and its columns as space separated values in increasing order, the
+// number of rows and columns, the weight and the p-value.  Results are written
+// in the order of 'SortResults'.
+func WriteDelimited(w io.Writer, results []*BiMaxResult, comma rune) error {
+	if comma == ' ' || comma == '"' || comma == '\n' {
+		return fmt.Errorf("%q cannot be used as a delimiter", comma)
+	}
+	sorted := append([]*BiMaxResult(nil), results...)
+	SortResults(sorted)
+
+	join := func(vv []int) string {
+		s := make([]string, len(vv))
+		for i, v := range vv {
+			s[i] = strconv.Itoa(v)
+		}
+		return strings.Join(s, " ")
+	}
+	float := func(x float64) string { return strconv.FormatFloat(x, 'g', -1, 64) }
+	bw := bufio.NewWriter(w)
+	sep := string(comma)
+	bw.WriteString(strings.Join([]string{"id", "rows", "cols", "n_rows", "n_cols", "weight", "p_value"}, sep) + "\n")
+	for i, r := range sorted {
+		bw.WriteString(strings.Join([]string{
+			strconv.Itoa(i + 1),
+			join(sortedValues(r.Rows)),
+			join(sortedValues(r.Cols)),
+			strconv.Itoa(r.Rows.Card()),
+			strconv.Itoa(r.Cols.Card()),
+			float(r.Weight),
+			float(r.PValue),
+		}, sep) + "\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,60 @@
+package bimax_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/maxsei/bimax"
+)
+
+func TestBiMaxResultJSON(t *testing.T) {
+	r := &bimax.BiMaxResult{
+		Rows:   bimax.NewSetWith(3, 1, 2).SetOp,
+		Cols:   bimax.NewSetWith(7, 5).SetOp,
+		Weight: 6,
+		PValue: 0.25,
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"rows":[1,2,3],"cols":[5,7],"n_rows":3,"n_cols":2,"weight":6,"p_value":0.25}`
+	if string(b) != want {
+		t.Fatalf("got %s want %s", b, want)
+	}
+	var got bimax.BiMaxResult
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Rows.IsEqual(r.Rows) || !got.Cols.IsEqual(r.Cols) || got.Weight != r.Weight || got.PValue != r.PValue {
+		t.Fatalf("got %v %v %v %v", got.Rows, got.Cols, got.Weight, got.PValue)
+	}
+
+	var set bimax.UnorderedSet
+	if err := json.Unmarshal([]byte("[4,2,4]"), &set); err != nil {
+		t.Fatal(err)
+	}
+	if !set.IsEqual(bimax.NewSetWith(2, 4)) {
+		t.Fatalf("got %v", &set)
+	}
+}
+
+func TestWriteTSV(t *testing.T) {
+	results := []*bimax.BiMaxResult{
+		{Rows: bimax.NewSetWith(2).SetOp, Cols: bimax.NewSetWith(5, 4).SetOp, Weight: 2},
+		{Rows: bimax.NewSetWith(1, 0).SetOp, Cols: bimax.NewSetWith(4, 3, 5).SetOp, Weight: 6},
+		{Rows: bimax.NewSetWith(0, 1).SetOp, Cols: bimax.NewSetWith(3).SetOp, Weight: 2},
+	}
+	var b bytes.Buffer
+	if err := bimax.WriteTSV(&b, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "id\trows\tcols\tn_rows\tn_cols\tweight\tp_value\n" +
+		"1\t0 1\t3 4 5\t2\t3\t6\t0\n" +
+		"2\t0 1\t3\t2\t1\t2\t0\n" +
+		"3\t2\t4 5\t1\t2\t2\t0\n"
+	if b.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
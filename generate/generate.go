@@ -0,0 +1,176 @@
+// Package generate produces random binary matrices for bimax, optionally with
+// planted bicliques whose rows and columns are returned as the ground truth.
+// Matrices are n by m slices of 1's and 0's in the row major form taken by
+// 'bimax.BiMaxBinaryMatrix' and the same seed always gives the same matrix.
+package generate
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/maxsei/bimax"
+)
+
+// ErdosRenyi returns an n by m binary matrix where every entry is 1
+// independently with probability p.  An error is returned if a size is
+// negative or p is not in [0, 1].
+func ErdosRenyi(n, m int, p float64, seed int64) ([]uint8, error) {
+	switch {
+	case n < 0 || m < 0:
+		return nil, fmt.Errorf("matrix size [%d, %d] is negative", n, m)
+	case !(0 <= p && p <= 1):
+		return nil, fmt.Errorf("probability %v is not in [0, 1]", p)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	data := make([]uint8, n*m)
+	fill(rng, data, p)
+	return data, nil
+}
+
+// PowerLaw returns an n by m binary matrix with the given expected density
+// whose row and column degrees follow a power law with exponent alpha > 1.
+// Entry (i, j) is 1 with probability proportional to the product of the
+// weights (i+1)^(-1/(alpha-1)) and (j+1)^(-1/(alpha-1)) so rows and columns
+// are ordered by non-increasing expected degree.  Probabilities above 1 are
+// clamped to 1, so for a small alpha or a large density the density of the
+// matrix falls short of the one given.  An error is returned if a size is
+// negative, the density is not in [0, 1] or alpha is not greater than 1.
+func PowerLaw(n, m int, density, alpha float64, seed int64) ([]uint8, error) {
+	switch {
+	case n < 0 || m < 0:
+		return nil, fmt.Errorf("matrix size [%d, %d] is negative", n, m)
+	case !(0 <= density && density <= 1):
+		return nil, fmt.Errorf("density %v is not in [0, 1]", density)
+	case !(alpha > 1):
+		return nil, fmt.Errorf("power law exponent %v is not greater than 1", alpha)
+	}
+	weights := func(k int) (w []float64, total float64) {
+		w = make([]float64, k)
+		for i := range w {
+			w[i] = math.Pow(float64(i+1), -1/(alpha-1))
+			total += w[i]
+		}
+		return
+	}
+	rowWeights, rowTotal := weights(n)
+	colWeights, colTotal := weights(m)
+	scale := density * float64(n*m) / (rowTotal * colTotal)
+
+	rng := rand.New(rand.NewSource(seed))
+	data := make([]uint8, n*m)
+	for i, wi := range rowWeights {
+		for j, wj := range colWeights {
+			if rng.Float64() < math.Min(1, scale*wi*wj) {
+				data[i*m+j] = 1
+			}
+		}
+	}
+	return data, nil
+}
+
+// PlantedOptions configures the bicliques planted by 'Planted'.
+type PlantedOptions struct {
+	// K is the number of bicliques to plant.
+	K int
+	// Rows and Cols are the number of rows and columns in each biclique.
+	Rows, Cols int
+	// Overlap is the fraction of the rows and of the columns that each
+	// biclique shares with the one planted before it.
+	Overlap float64
+	// Density is the probability of a 1 in the background outside of the
+	// planted bicliques.
+	Density float64
+	// Noise is the probability of flipping each entry of the matrix after the
+	// bicliques are planted.
+	Noise float64
+	// Seed seeds the random background, placement and noise.
+	Seed int64
+}
+
+// Planted returns an n by m binary matrix with planted bicliques along with
+// the rows and columns of each planted biclique.  As in the results of
+// 'bimax.BiMaxBinaryMatrix' rows are numbered [0, n) and columns [n, n+m).
+// An error is returned if an option is out of range or if the bicliques do
+// not fit into the matrix.
+func Planted(n, m int, opts PlantedOptions) (data []uint8, truth []*bimax.BiMaxResult, err error) {
+	switch {
+	case n < 0 || m < 0:
+		return nil, nil, fmt.Errorf("matrix size [%d, %d] is negative", n, m)
+	case opts.K < 0:
+		return nil, nil, fmt.Errorf("number of bicliques %d is negative", opts.K)
+	case opts.K > 0 && (opts.Rows < 1 || opts.Cols < 1):
+		return nil, nil, fmt.Errorf("biclique size [%d, %d] is not positive", opts.Rows, opts.Cols)
+	case !(0 <= opts.Overlap && opts.Overlap < 1):
+		return nil, nil, fmt.Errorf("overlap %v is not in [0, 1)", opts.Overlap)
+	case !(0 <= opts.Density && opts.Density <= 1):
+		return nil, nil, fmt.Errorf("density %v is not in [0, 1]", opts.Density)
+	case !(0 <= opts.Noise && opts.Noise <= 1):
+		return nil, nil, fmt.Errorf("noise %v is not in [0, 1]", opts.Noise)
+	}
+	rowOverlap := int(math.Round(opts.Overlap * float64(opts.Rows)))
+	colOverlap := int(math.Round(opts.Overlap * float64(opts.Cols)))
+	if opts.K > 1 && (rowOverlap == opts.Rows || colOverlap == opts.Cols) {
+		return nil, nil, fmt.Errorf("overlap %v of bicliques of [%d, %d] makes them equal",
+			opts.Overlap, opts.Rows, opts.Cols)
+	}
+	// The span of rows and columns taken by all of the bicliques.
+	rowSpan := opts.K*(opts.Rows-rowOverlap) + rowOverlap
+	colSpan := opts.K*(opts.Cols-colOverlap) + colOverlap
+	if opts.K > 0 && (n < rowSpan || m < colSpan) {
+		return nil, nil, fmt.Errorf("%d bicliques of [%d, %d] with overlap %v do not fit into [%d, %d]",
+			opts.K, opts.Rows, opts.Cols, opts.Overlap, n, m)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	data = make([]uint8, n*m)
+	fill(rng, data, opts.Density)
+
+	// Bicliques are laid out along the diagonal of randomly permuted rows and
+	// columns.
+	rowPerm, colPerm := rng.Perm(n), rng.Perm(m)
+	truth = make([]*bimax.BiMaxResult, 0, opts.K)
+	for k := 0; k < opts.K; k++ {
+		rows, cols := bimax.NewSet(), bimax.NewSet()
+		for i := 0; i < opts.Rows; i++ {
+			rows.Add(rowPerm[k*(opts.Rows-rowOverlap)+i])
+		}
+		for j := 0; j < opts.Cols; j++ {
+			cols.Add(colPerm[k*(opts.Cols-colOverlap)+j])
+		}
+		rows.Each(func(i int) (_ bool) {
+			cols.Each(func(j int) (_ bool) {
+				data[i*m+j] = 1
+				return
+			})
+			return
+		})
+		// Columns are offset by n to match the graph vertices of bimax.
+		vertices := bimax.NewSet()
+		cols.Each(func(j int) (_ bool) {
+			vertices.Add(j + n)
+			return
+		})
+		truth = append(truth, &bimax.BiMaxResult{
+			Rows:   rows.SetOp,
+			Cols:   vertices.SetOp,
+			Weight: float64(opts.Rows * opts.Cols),
+		})
+	}
+
+	for i := range data {
+		if rng.Float64() < opts.Noise {
+			data[i] ^= 1
+		}
+	}
+	return
+}
+
+// fill sets every entry of data to 1 with probability p.
+func fill(rng *rand.Rand, data []uint8, p float64) {
+	for i := range data {
+		if rng.Float64() < p {
+			data[i] = 1
+		}
+	}
+}
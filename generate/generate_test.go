@@ -0,0 +1,198 @@
+package generate_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/generate"
+)
+
+func density(data []uint8) float64 {
+	ones := 0
+	for _, x := range data {
+		ones += int(x)
+	}
+	return float64(ones) / float64(len(data))
+}
+
+// shared returns the number of values in both a and b.
+func shared(a, b *bimax.SetOp) (count int) {
+	a.Each(func(v int) (_ bool) {
+		if b.Has(v) {
+			count++
+		}
+		return
+	})
+	return
+}
+
+// first returns any value of s.
+func first(s *bimax.SetOp) (v int) {
+	s.Each(func(x int) bool {
+		v = x
+		return true
+	})
+	return
+}
+
+func TestErdosRenyi(t *testing.T) {
+	data, err := generate.ErdosRenyi(100, 200, 0.3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 100*200 {
+		t.Fatalf("got %d entries, want %d", len(data), 100*200)
+	}
+	if d := density(data); math.Abs(d-0.3) > 0.02 {
+		t.Errorf("got density %v, want about 0.3", d)
+	}
+	if again, _ := generate.ErdosRenyi(100, 200, 0.3, 1); !bytes.Equal(data, again) {
+		t.Error("the same seed gave different matrices")
+	}
+
+	for _, tc := range []struct {
+		name string
+		n, m int
+		p    float64
+	}{
+		{"negative size", 10, -1, 0.5},
+		{"negative p", 10, 10, -0.1},
+		{"p above 1", 10, 10, 1.5},
+		{"NaN p", 10, 10, math.NaN()},
+	} {
+		if _, err := generate.ErdosRenyi(tc.n, tc.m, tc.p, 1); err == nil {
+			t.Errorf("%s: no error", tc.name)
+		}
+	}
+}
+
+func TestPowerLaw(t *testing.T) {
+	const n, m = 100, 100
+	data, err := generate.PowerLaw(n, m, 0.1, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := density(data); math.Abs(d-0.1) > 0.02 {
+		t.Errorf("got density %v, want about 0.1", d)
+	}
+	// The first rows are expected to have the largest degrees.
+	if first, last := density(data[:10*m]), density(data[(n-10)*m:]); first <= last {
+		t.Errorf("density of the first rows %v is not above that of the last %v", first, last)
+	}
+	if again, _ := generate.PowerLaw(n, m, 0.1, 3, 1); !bytes.Equal(data, again) {
+		t.Error("the same seed gave different matrices")
+	}
+
+	// A small exponent asks for probabilities above 1 in the first rows and
+	// columns, which fill up while the density falls short.
+	data, err = generate.PowerLaw(n, m, 0.5, 1.2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0] != 1 || data[m+1] != 1 {
+		t.Error("entries with a clamped probability of 1 are 0")
+	}
+	if d := density(data); d >= 0.5 {
+		t.Errorf("got density %v, want below 0.5", d)
+	}
+
+	for _, tc := range []struct {
+		name           string
+		n, m           int
+		density, alpha float64
+	}{
+		{"negative size", -1, 10, 0.1, 3},
+		{"density", 10, 10, 1.5, 3},
+		{"exponent 1", 10, 10, 0.1, 1},
+		{"NaN exponent", 10, 10, 0.1, math.NaN()},
+	} {
+		if _, err := generate.PowerLaw(tc.n, tc.m, tc.density, tc.alpha, 1); err == nil {
+			t.Errorf("%s: no error", tc.name)
+		}
+	}
+}
+
+func TestPlanted(t *testing.T) {
+	const n, m = 50, 40
+	opts := generate.PlantedOptions{K: 3, Rows: 10, Cols: 8, Overlap: 0.2, Density: 0.1, Seed: 1}
+	data, truth, err := generate.Planted(n, m, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(truth) != opts.K {
+		t.Fatalf("got %d bicliques, want %d", len(truth), opts.K)
+	}
+	for k, b := range truth {
+		if b.Rows.Card() != opts.Rows || b.Cols.Card() != opts.Cols || b.Weight != float64(opts.Rows*opts.Cols) {
+			t.Errorf("biclique %d: got [%d, %d] of weight %v", k, b.Rows.Card(), b.Cols.Card(), b.Weight)
+		}
+		b.Rows.Each(func(i int) (_ bool) {
+			b.Cols.Each(func(j int) (_ bool) {
+				if j < n || n+m <= j {
+					t.Fatalf("biclique %d: column %d is not in [%d, %d)", k, j, n, n+m)
+				}
+				if data[i*m+j-n] != 1 {
+					t.Fatalf("biclique %d: entry (%d, %d) is 0", k, i, j-n)
+				}
+				return
+			})
+			return
+		})
+		if k == 0 {
+			continue
+		}
+		// Each biclique shares 2 of 10 rows and 2 of 8 columns with the one
+		// before it.
+		prev := truth[k-1]
+		rows, cols := shared(b.Rows, prev.Rows), shared(b.Cols, prev.Cols)
+		if rows != 2 || cols != 2 {
+			t.Errorf("biclique %d: shares %d rows and %d columns, want 2 and 2", k, rows, cols)
+		}
+	}
+
+	again, _, _ := generate.Planted(n, m, opts)
+	if !bytes.Equal(data, again) {
+		t.Error("the same seed gave different matrices")
+	}
+
+	// Flipping every entry leaves the bicliques empty.
+	opts.Noise = 1
+	data, truth, err = generate.Planted(n, m, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, j := first(truth[0].Rows), first(truth[0].Cols); data[i*m+j-n] != 0 {
+		t.Error("noise 1 did not flip a planted entry")
+	}
+}
+
+func TestPlantedErrors(t *testing.T) {
+	valid := generate.PlantedOptions{K: 2, Rows: 4, Cols: 4, Overlap: 0.5, Density: 0.1, Noise: 0.01}
+	for _, tc := range []struct {
+		name string
+		n, m int
+		edit func(opts *generate.PlantedOptions)
+	}{
+		{"negative size", -1, 10, func(*generate.PlantedOptions) {}},
+		{"negative K", 10, 10, func(opts *generate.PlantedOptions) { opts.K = -1 }},
+		{"empty biclique", 10, 10, func(opts *generate.PlantedOptions) { opts.Cols = 0 }},
+		{"overlap 1", 10, 10, func(opts *generate.PlantedOptions) { opts.Overlap = 1 }},
+		{"negative overlap", 10, 10, func(opts *generate.PlantedOptions) { opts.Overlap = -0.5 }},
+		{"overlap rounds to 1", 10, 10, func(opts *generate.PlantedOptions) { opts.Overlap = 0.9 }},
+		{"density", 10, 10, func(opts *generate.PlantedOptions) { opts.Density = 1.5 }},
+		{"noise", 10, 10, func(opts *generate.PlantedOptions) { opts.Noise = -0.1 }},
+		{"NaN noise", 10, 10, func(opts *generate.PlantedOptions) { opts.Noise = math.NaN() }},
+		{"does not fit", 5, 10, func(*generate.PlantedOptions) {}},
+	} {
+		opts := valid
+		tc.edit(&opts)
+		if _, _, err := generate.Planted(tc.n, tc.m, opts); err == nil {
+			t.Errorf("%s: no error", tc.name)
+		}
+	}
+	if _, _, err := generate.Planted(10, 10, valid); err != nil {
+		t.Errorf("valid options: %v", err)
+	}
+}
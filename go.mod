@@ -1,5 +1,5 @@
 module github.com/maxsei/bimax
 
-go 1.15
+go 1.24.0
 
 require github.com/yourbasic/graph v0.0.0-20170921192928-40eb135c0b26
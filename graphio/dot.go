@@ -0,0 +1,450 @@
+package graphio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/maxsei/bimax"
+)
+
+// palette colors the bicliques written by 'WriteDOT'.
+var palette = []string{
+	"#e41a1c", "#377eb8", "#4daf4a", "#984ea3", "#ff7f00", "#a65628", "#f781bf", "#999999",
+}
+
+// ReadDOT reads a graph or digraph in the Graphviz DOT language, treating
+// every edge as undirected.  The side of a node is read from a "bipartite"
+// attribute holding 0 or 1 and otherwise found by 2-coloring the graph.  The
+// label of a node is read from its "label" attribute and otherwise is the
+// node id.  Subgraphs are read as groups of statements, ports are ignored and
+// subgraphs may not be used as the end of an edge.
+func ReadDOT(r io.Reader) (*Graph, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{lex: newDotLexer(string(src)), raw: newRawGraph()}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", p.lex.line, err)
+	}
+	return p.raw.build()
+}
+
+// dotToken is a token of the DOT language.  Identifiers, numerals and quoted
+// or HTML strings have the kind 'i' and punctuation the kind of its first
+// character.
+type dotToken struct {
+	kind   rune
+	value  string
+	quoted bool
+}
+
+// keyword returns the value of an unquoted identifier in lower case, since
+// keywords are case insensitive and a quoted or HTML string is never one.
+func (t dotToken) keyword() string {
+	if t.kind != 'i' || t.quoted {
+		return ""
+	}
+	return strings.ToLower(t.value)
+}
+
+type dotLexer struct {
+	src  string
+	pos  int
+	line int
+	peek *dotToken
+}
+
+func newDotLexer(src string) *dotLexer { return &dotLexer{src: src, line: 1} }
+
+func (l *dotLexer) next() (dotToken, error) {
+	if l.peek != nil {
+		t := *l.peek
+		l.peek = nil
+		return t, nil
+	}
+	return l.scan()
+}
+
+func (l *dotLexer) lookahead() (dotToken, error) {
+	if l.peek == nil {
+		t, err := l.scan()
+		if err != nil {
+			return t, err
+		}
+		l.peek = &t
+	}
+	return *l.peek, nil
+}
+
+func (l *dotLexer) scan() (dotToken, error) {
+	l.skip()
+	if l.pos >= len(l.src) {
+		return dotToken{kind: -1}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case c == '"':
+		return l.quoted()
+	case c == '<':
+		return l.html()
+	case c == '-' && l.pos+1 < len(l.src) && (l.src[l.pos+1] == '-' || l.src[l.pos+1] == '>'):
+		l.pos += 2
+		return dotToken{kind: '-', value: "--"}, nil
+	case strings.IndexByte("{}[];,=:", c) >= 0:
+		l.pos++
+		return dotToken{kind: rune(c), value: string(c)}, nil
+	}
+	start := l.pos
+	for l.pos < len(l.src) {
+		r := rune(l.src[l.pos])
+		if !(r == '_' || r == '.' || r == '-' || r >= 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			break
+		}
+		// A '-' starting an edge operator ends the identifier.
+		if r == '-' && l.pos+1 < len(l.src) && (l.src[l.pos+1] == '-' || l.src[l.pos+1] == '>') {
+			break
+		}
+		l.pos++
+	}
+	if l.pos == start {
+		return dotToken{}, fmt.Errorf("unexpected character %q", c)
+	}
+	return dotToken{kind: 'i', value: l.src[start:l.pos]}, nil
+}
+
+// skip skips white space and comments.
+func (l *dotLexer) skip() {
+	atLineStart := l.pos == 0 || l.src[l.pos-1] == '\n'
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+			atLineStart = true
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+			continue
+		case c == '#' && atLineStart:
+			l.skipUntil("\n")
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			l.skipUntil("\n")
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			l.skipUntil("*/")
+			l.pos += 2
+		default:
+			return
+		}
+		atLineStart = false
+	}
+}
+
+func (l *dotLexer) skipUntil(end string) {
+	for l.pos < len(l.src) && !strings.HasPrefix(l.src[l.pos:], end) {
+		if l.src[l.pos] == '\n' && end != "\n" {
+			l.line++
+		}
+		l.pos++
+	}
+}
+
+func (l *dotLexer) quoted() (dotToken, error) {
+	var b strings.Builder
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return dotToken{kind: 'i', value: b.String(), quoted: true}, nil
+		case c == '\\' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '"':
+			b.WriteByte('"')
+			l.pos++
+		case c == '\\' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '\n':
+			// A backslash newline continues the string.
+			l.pos++
+			l.line++
+		default:
+			if c == '\n' {
+				l.line++
+			}
+			b.WriteByte(c)
+		}
+	}
+	return dotToken{}, fmt.Errorf("unterminated string")
+}
+
+func (l *dotLexer) html() (dotToken, error) {
+	start, depth := l.pos, 0
+	for ; l.pos < len(l.src); l.pos++ {
+		switch l.src[l.pos] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '\n':
+			l.line++
+		}
+		if depth == 0 {
+			l.pos++
+			return dotToken{kind: 'i', value: l.src[start+1 : l.pos-1], quoted: true}, nil
+		}
+	}
+	return dotToken{}, fmt.Errorf("unterminated HTML string")
+}
+
+type dotParser struct {
+	lex *dotLexer
+	raw *rawGraph
+}
+
+func (p *dotParser) expect(kind rune) (dotToken, error) {
+	t, err := p.lex.next()
+	if err != nil {
+		return t, err
+	}
+	if t.kind != kind {
+		return t, fmt.Errorf("unexpected %q", t.value)
+	}
+	return t, nil
+}
+
+func (p *dotParser) parse() error {
+	t, err := p.expect('i')
+	if err != nil {
+		return err
+	}
+	if t.keyword() == "strict" {
+		if t, err = p.expect('i'); err != nil {
+			return err
+		}
+	}
+	if kw := t.keyword(); kw != "graph" && kw != "digraph" {
+		return fmt.Errorf("expected graph or digraph not %q", t.value)
+	}
+	if t, err = p.lex.lookahead(); err != nil {
+		return err
+	}
+	if t.kind == 'i' {
+		p.lex.next()
+	}
+	if _, err := p.expect('{'); err != nil {
+		return err
+	}
+	return p.statements()
+}
+
+// statements parses statements up to and including the closing brace.
+func (p *dotParser) statements() error {
+	for {
+		t, err := p.lex.next()
+		if err != nil {
+			return err
+		}
+		switch t.kind {
+		case '}':
+			return nil
+		case ';':
+			continue
+		case '{':
+			if err := p.statements(); err != nil {
+				return err
+			}
+			continue
+		case 'i':
+		default:
+			return fmt.Errorf("unexpected %q", t.value)
+		}
+
+		switch t.keyword() {
+		case "graph", "node", "edge":
+			if _, err := p.attributes(); err != nil {
+				return err
+			}
+			continue
+		case "subgraph":
+			if t, err = p.lex.next(); err != nil {
+				return err
+			}
+			if t.kind == 'i' {
+				t, err = p.lex.next()
+				if err != nil {
+					return err
+				}
+			}
+			if t.kind != '{' {
+				return fmt.Errorf("unexpected %q", t.value)
+			}
+			if err := p.statements(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		next, err := p.lex.lookahead()
+		if err != nil {
+			return err
+		}
+		if next.kind == '=' {
+			// Graph attribute.
+			p.lex.next()
+			if _, err := p.expect('i'); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.nodeOrEdge(t.value); err != nil {
+			return err
+		}
+	}
+}
+
+// nodeOrEdge parses a node statement or a chain of edges starting at id.
+func (p *dotParser) nodeOrEdge(id string) error {
+	nodes := []int{p.raw.node(id)}
+	for {
+		if err := p.port(); err != nil {
+			return err
+		}
+		t, err := p.lex.lookahead()
+		if err != nil {
+			return err
+		}
+		if t.kind != '-' {
+			break
+		}
+		p.lex.next()
+		t, err = p.expect('i')
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, p.raw.node(t.value))
+	}
+	attrs, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	if len(nodes) > 1 {
+		for i := 1; i < len(nodes); i++ {
+			p.raw.edge(nodes[i-1], nodes[i])
+		}
+		return nil
+	}
+	v := nodes[0]
+	if label, ok := attrs["label"]; ok {
+		p.raw.labels[v] = label
+	}
+	if value, ok := attrs["bipartite"]; ok {
+		side, err := strconv.Atoi(value)
+		if err != nil || (side != 0 && side != 1) {
+			return fmt.Errorf("node %q: bipartite attribute %q is not 0 or 1", p.raw.ids[v], value)
+		}
+		p.raw.side[v] = side
+	}
+	return nil
+}
+
+// port skips the port of a node id.
+func (p *dotParser) port() error {
+	for {
+		t, err := p.lex.lookahead()
+		if err != nil || t.kind != ':' {
+			return err
+		}
+		p.lex.next()
+		if _, err := p.expect('i'); err != nil {
+			return err
+		}
+	}
+}
+
+// attributes parses any attribute lists that follow.
+func (p *dotParser) attributes() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for {
+		t, err := p.lex.lookahead()
+		if err != nil {
+			return nil, err
+		}
+		if t.kind != '[' {
+			return attrs, nil
+		}
+		p.lex.next()
+		for {
+			t, err := p.lex.next()
+			if err != nil {
+				return nil, err
+			}
+			if t.kind == ']' {
+				break
+			}
+			if t.kind == ';' || t.kind == ',' {
+				continue
+			}
+			if t.kind != 'i' {
+				return nil, fmt.Errorf("unexpected %q", t.value)
+			}
+			if _, err := p.expect('='); err != nil {
+				return nil, err
+			}
+			value, err := p.expect('i')
+			if err != nil {
+				return nil, err
+			}
+			attrs[t.value] = value.value
+		}
+	}
+}
+
+// WriteDOT writes g as an undirected DOT graph.  Every node has a "label" and
+// a "bipartite" side attribute.  Each of results is drawn as a subgraph
+// cluster in its own color with its edges highlighted.  A node in several
+// results is placed in the cluster of the first.
+func WriteDOT(w io.Writer, g *Graph, results ...*bimax.BiMaxResult) error {
+	bw := bufio.NewWriter(w)
+	id := func(v int) string { return "n" + strconv.Itoa(v) }
+	node := func(indent string, v int) {
+		fmt.Fprintf(bw, "%s%s [label=%s, bipartite=%d];\n", indent, id(v), quote(g.Label(v)), g.Side(v))
+	}
+
+	fmt.Fprintln(bw, "graph G {")
+	placed := make(map[int]bool)
+	for i, r := range results {
+		color := palette[i%len(palette)]
+		fmt.Fprintf(bw, "  subgraph cluster_%d {\n", i+1)
+		fmt.Fprintf(bw, "    label=%s;\n    color=%s;\n", quote(fmt.Sprintf("biclique %d", i+1)), quote(color))
+		for _, set := range []*bimax.SetOp{r.Rows, r.Cols} {
+			for _, v := range sortedValues(set) {
+				if !placed[v] {
+					placed[v] = true
+					node("    ", v)
+				}
+			}
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+	for v := 0; v < g.G.Order(); v++ {
+		if !placed[v] {
+			node("  ", v)
+		}
+	}
+	g.edges(func(u, v int) {
+		if member := edgeMembership(u, v, results); member != nil {
+			color := palette[(member[0]-1)%len(palette)]
+			fmt.Fprintf(bw, "  %s -- %s [color=%s, penwidth=2];\n", id(u), id(v), quote(color))
+			return
+		}
+		fmt.Fprintf(bw, "  %s -- %s;\n", id(u), id(v))
+	})
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func quote(s string) string {
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
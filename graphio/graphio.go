@@ -0,0 +1,175 @@
+// Package graphio reads and writes labelled bipartite graphs as TSV edge
+// lists, GraphML and Graphviz DOT.  Writers can highlight the bicliques found
+// by bimax.
+package graphio
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/maxsei/bimax"
+	"github.com/yourbasic/graph"
+)
+
+// Graph is a bipartite graph G of (U ∪ V, E(G)) with a label for each vertex.
+type Graph struct {
+	G      *graph.Mutable
+	U, V   *bimax.UnorderedSet
+	Labels []string
+}
+
+// BiMax finds the maximal biclique of g.
+func (g *Graph) BiMax() *bimax.BiMaxResult { return bimax.BiMax(g.G, g.U, g.V) }
+
+// Label returns the label of vertex v.
+func (g *Graph) Label(v int) string { return g.Labels[v] }
+
+// Side returns 0 if v ∈ U and 1 if v ∈ V.
+func (g *Graph) Side(v int) int {
+	if g.V.Has(v) {
+		return 1
+	}
+	return 0
+}
+
+// edges calls do with every edge (u, v) of g where u ∈ U, in increasing order
+// of u and then v.
+func (g *Graph) edges(do func(u, v int)) {
+	for u := 0; u < g.G.Order(); u++ {
+		if !g.U.Has(u) {
+			continue
+		}
+		var neighbors []int
+		g.G.Visit(u, func(v int, _ int64) (_ bool) {
+			neighbors = append(neighbors, v)
+			return
+		})
+		sort.Ints(neighbors)
+		for _, v := range neighbors {
+			do(u, v)
+		}
+	}
+}
+
+// rawGraph collects the vertices and edges of a file before the vertices are
+// split into the sides of the bipartite graph.
+type rawGraph struct {
+	index  map[string]int
+	ids    []string
+	labels []string
+	// side is 0 or 1 when the file gives the side of a vertex and -1 when it
+	// is not known.
+	side  []int
+	edges [][2]int
+}
+
+func newRawGraph() *rawGraph { return &rawGraph{index: make(map[string]int)} }
+
+// node returns the vertex of the node id, adding it if it is new.
+func (raw *rawGraph) node(id string) int {
+	if v, ok := raw.index[id]; ok {
+		return v
+	}
+	v := len(raw.ids)
+	raw.index[id] = v
+	raw.ids = append(raw.ids, id)
+	raw.labels = append(raw.labels, id)
+	raw.side = append(raw.side, -1)
+	return v
+}
+
+func (raw *rawGraph) edge(u, v int) { raw.edges = append(raw.edges, [2]int{u, v}) }
+
+// build splits the vertices into U and V.  Vertices without a known side are
+// 2-colored starting from a vertex of known side, or from U for a component
+// where no side is known.
+func (raw *rawGraph) build() (*Graph, error) {
+	n := len(raw.ids)
+	G := graph.New(n)
+	for _, e := range raw.edges {
+		if e[0] == e[1] {
+			return nil, fmt.Errorf("self loop at node %q", raw.ids[e[0]])
+		}
+		G.AddBoth(e[0], e[1])
+	}
+
+	side := append([]int(nil), raw.side...)
+	color := func(start int) error {
+		queue := []int{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			var err error
+			G.Visit(u, func(v int, _ int64) bool {
+				switch side[v] {
+				case -1:
+					side[v] = 1 - side[u]
+					queue = append(queue, v)
+				case side[u]:
+					err = fmt.Errorf("edge between %q and %q is within one side of the graph", raw.ids[u], raw.ids[v])
+					return true
+				}
+				return false
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// Known sides are spread first so that they are never contradicted by a
+	// guess.
+	for v := 0; v < n; v++ {
+		if raw.side[v] != -1 {
+			if err := color(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		if side[v] == -1 {
+			side[v] = 0
+			if err := color(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	g := &Graph{G: G, U: bimax.NewSet(), V: bimax.NewSet(), Labels: raw.labels}
+	for v, s := range side {
+		if s == 0 {
+			g.U.Add(v)
+		} else {
+			g.V.Add(v)
+		}
+	}
+	return g, nil
+}
+
+// membership returns the indices, counted from 1, of the results that contain
+// vertex v.
+func membership(v int, results []*bimax.BiMaxResult) (member []int) {
+	for i, r := range results {
+		if r.Rows.Has(v) || r.Cols.Has(v) {
+			member = append(member, i+1)
+		}
+	}
+	return
+}
+
+// edgeMembership returns the indices, counted from 1, of the results that
+// contain the edge (u, v).
+func edgeMembership(u, v int, results []*bimax.BiMaxResult) (member []int) {
+	for i, r := range results {
+		if (r.Rows.Has(u) && r.Cols.Has(v)) || (r.Rows.Has(v) && r.Cols.Has(u)) {
+			member = append(member, i+1)
+		}
+	}
+	return
+}
+
+func sortedValues(set *bimax.SetOp) []int {
+	vv := set.Values()
+	sort.Ints(vv)
+	return vv
+}
@@ -0,0 +1,181 @@
+package graphio_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/graphio"
+)
+
+// edges returns the edges of g as the labels of their vertex in U and in V.
+func edges(g *graphio.Graph) []string {
+	var result []string
+	g.U.Each(func(u int) (_ bool) {
+		g.G.Visit(u, func(v int, _ int64) (_ bool) {
+			result = append(result, g.Label(u)+"-"+g.Label(v))
+			return
+		})
+		return
+	})
+	sort.Strings(result)
+	return result
+}
+
+// A gene by condition graph where "a" names a vertex of each side.
+const tsv = "# gene\tcondition\nBRCA1\ta\nBRCA1\tb\nTP53\ta\nTP53\tb\na\tb\nMYC c extra\n"
+
+var tsvEdges = []string{"BRCA1-a", "BRCA1-b", "MYC-c", "TP53-a", "TP53-b", "a-b"}
+
+func TestTSV(t *testing.T) {
+	g, err := graphio.ReadTSV(strings.NewReader(tsv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := edges(g); !reflect.DeepEqual(got, tsvEdges) {
+		t.Fatalf("got %v, want %v", got, tsvEdges)
+	}
+	if g.U.Card() != 4 || g.V.Card() != 3 {
+		t.Errorf("got %d vertices in U and %d in V, want 4 and 3", g.U.Card(), g.V.Card())
+	}
+	if r := g.BiMax(); r.Weight != 4 {
+		t.Errorf("got %v x %v, want an area of 4", r.Rows, r.Cols)
+	}
+
+	var b bytes.Buffer
+	if err := graphio.WriteTSV(&b, g); err != nil {
+		t.Fatal(err)
+	}
+	h, err := graphio.ReadTSV(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := edges(h); !reflect.DeepEqual(got, tsvEdges) {
+		t.Errorf("round trip: got %v, want %v", got, tsvEdges)
+	}
+}
+
+// roundTrip writes the graph of tsv along with its largest biclique and reads
+// it back.
+func roundTrip(t *testing.T, name string, write func(*bytes.Buffer, *graphio.Graph, *bimax.BiMaxResult) error, read func(*bytes.Buffer) (*graphio.Graph, error)) {
+	t.Helper()
+	g, err := graphio.ReadTSV(strings.NewReader(tsv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := write(&b, g, g.BiMax()); err != nil {
+		t.Fatal(err)
+	}
+	h, err := read(&b)
+	if err != nil {
+		t.Fatalf("%s: %v\n%s", name, err, b.String())
+	}
+	if got := edges(h); !reflect.DeepEqual(got, tsvEdges) {
+		t.Errorf("%s: got %v, want %v", name, got, tsvEdges)
+	}
+	if got, want := vertices(h), vertices(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: got vertices %v, want %v", name, got, want)
+	}
+}
+
+// vertices returns the labels of the vertices of g prefixed by their side.
+func vertices(g *graphio.Graph) []string {
+	result := make([]string, g.G.Order())
+	for v := range result {
+		result[v] = fmt.Sprintf("%d:%s", g.Side(v), g.Label(v))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestGraphML(t *testing.T) {
+	roundTrip(t, "graphml",
+		func(b *bytes.Buffer, g *graphio.Graph, r *bimax.BiMaxResult) error { return graphio.WriteGraphML(b, g, r) },
+		func(b *bytes.Buffer) (*graphio.Graph, error) { return graphio.ReadGraphML(b) })
+
+	// Without a bipartite attribute the sides are found by 2-coloring.
+	doc := `<graphml><key id="d0" for="node" attr.name="name"/><graph edgedefault="undirected">
+		<node id="1"><data key="d0">x</data></node><node id="2"/><node id="3"/>
+		<edge source="1" target="2"/><edge source="2" target="3"/>
+	</graph></graphml>`
+	g, err := graphio.ReadGraphML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := edges(g), []string{"3-2", "x-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDOT(t *testing.T) {
+	roundTrip(t, "dot",
+		func(b *bytes.Buffer, g *graphio.Graph, r *bimax.BiMaxResult) error { return graphio.WriteDOT(b, g, r) },
+		func(b *bytes.Buffer) (*graphio.Graph, error) { return graphio.ReadDOT(b) })
+
+	src := `/* genes */ strict digraph "expression" {
+# a preprocessor line
+	graph [rankdir=LR]; rankdir=LR
+	node [shape=box]
+	g1 [label="BRCA\"1\"", bipartite=0]
+	subgraph cluster_1 { g2:n -> c1:s -> g3 }
+	{ g1 -- c1 } // trailing comment
+	g1 -> <c<b>2</b>>
+	c1 [bipartite=1];
+}`
+	g, err := graphio.ReadDOT(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`BRCA"1"-c1`, `BRCA"1"-c<b>2</b>`, "g2-c1", "g3-c1"}
+	if got := edges(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Only unquoted identifiers are keywords.
+	src = `GRAPH { "node" [bipartite=0]; "node" -- "Edge"; <subgraph> -- "graph" [bipartite=1] }`
+	if g, err = graphio.ReadDOT(strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"node-Edge", "subgraph-graph"}
+	if got := edges(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		read  func(string) (*graphio.Graph, error)
+		input string
+	}{
+		{"tsv one field", tsvString, "BRCA1\n"},
+		{"graphml syntax", graphMLString, "<graphml><graph>"},
+		{"graphml side", graphMLString, `<graphml><key id="b" for="node" attr.name="bipartite"/><graph><node id="1"><data key="b">2</data></node></graph></graphml>`},
+		{"graphml odd cycle", graphMLString, `<graphml><graph><edge source="1" target="2"/><edge source="2" target="3"/><edge source="3" target="1"/></graph></graphml>`},
+		{"graphml self loop", graphMLString, `<graphml><graph><edge source="1" target="1"/></graph></graphml>`},
+		{"dot empty", dotString, ""},
+		{"dot keyword", dotString, "tree { a -- b }"},
+		{"dot quoted keyword", dotString, `"graph" { a -- b }`},
+		{"dot unclosed", dotString, "graph { a -- b"},
+		{"dot string", dotString, `graph { "a -- b }`},
+		{"dot html", dotString, "graph { <a -- b }"},
+		{"dot character", dotString, "graph { a -- b; @ }"},
+		{"dot edge to subgraph", dotString, "graph { a -- { b } }"},
+		{"dot attribute", dotString, "graph { a [label] }"},
+		{"dot side", dotString, "graph { a [bipartite=x] }"},
+		{"dot same side", dotString, "graph { a [bipartite=0]; b [bipartite=0]; a -- b }"},
+	} {
+		if g, err := tt.read(tt.input); err == nil {
+			t.Errorf("%s: got %v and no error", tt.name, edges(g))
+		}
+	}
+}
+
+func tsvString(s string) (*graphio.Graph, error)     { return graphio.ReadTSV(strings.NewReader(s)) }
+func graphMLString(s string) (*graphio.Graph, error) { return graphio.ReadGraphML(strings.NewReader(s)) }
+func dotString(s string) (*graphio.Graph, error)     { return graphio.ReadDOT(strings.NewReader(s)) }
@@ -0,0 +1,143 @@
+package graphio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/maxsei/bimax"
+)
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ReadGraphML reads the first graph of a GraphML document.  The side of a node
+// is read from a node attribute named "bipartite" holding 0 or 1, as written
+// by NetworkX, and otherwise found by 2-coloring the graph.  The label of a
+// node is read from a node attribute named "label" or "name" and otherwise is
+// the id of the node.
+func ReadGraphML(r io.Reader) (*Graph, error) {
+	var doc graphML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	// Node attributes used by this package by key id.
+	attrs := make(map[string]string)
+	for _, k := range doc.Keys {
+		if k.For == "node" || k.For == "all" {
+			attrs[k.ID] = k.Name
+		}
+	}
+
+	raw := newRawGraph()
+	for _, node := range doc.Graph.Nodes {
+		v := raw.node(node.ID)
+		for _, d := range node.Data {
+			value := strings.TrimSpace(d.Value)
+			switch attrs[d.Key] {
+			case "label", "name":
+				raw.labels[v] = value
+			case "bipartite":
+				side, err := strconv.Atoi(value)
+				if err != nil || (side != 0 && side != 1) {
+					return nil, fmt.Errorf("node %q: bipartite attribute %q is not 0 or 1", node.ID, value)
+				}
+				raw.side[v] = side
+			}
+		}
+	}
+	for _, edge := range doc.Graph.Edges {
+		raw.edge(raw.node(edge.Source), raw.node(edge.Target))
+	}
+	return raw.build()
+}
+
+// WriteGraphML writes g as an undirected GraphML graph.  Every node has a
+// "label" and a "bipartite" side attribute.  Nodes and edges that are part of
+// any of results have a "biclique" attribute listing the indices, counted from
+// 1, of the results that contain them.
+func WriteGraphML(w io.Writer, g *Graph, results ...*bimax.BiMaxResult) error {
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "bipartite", For: "node", Name: "bipartite", Type: "int"},
+			{ID: "nbiclique", For: "node", Name: "biclique", Type: "string"},
+			{ID: "ebiclique", For: "edge", Name: "biclique", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "G", EdgeDefault: "undirected"},
+	}
+	id := func(v int) string { return "n" + strconv.Itoa(v) }
+	for v := 0; v < g.G.Order(); v++ {
+		node := graphMLNode{ID: id(v), Data: []graphMLData{
+			{"label", g.Label(v)},
+			{"bipartite", strconv.Itoa(g.Side(v))},
+		}}
+		if member := membership(v, results); member != nil {
+			node.Data = append(node.Data, graphMLData{"nbiclique", joinInts(member)})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	g.edges(func(u, v int) {
+		edge := graphMLEdge{Source: id(u), Target: id(v)}
+		if member := edgeMembership(u, v, results); member != nil {
+			edge.Data = append(edge.Data, graphMLData{"ebiclique", joinInts(member)})
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	})
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func joinInts(xx []int) string {
+	s := make([]string, len(xx))
+	for i, x := range xx {
+		s[i] = strconv.Itoa(x)
+	}
+	return strings.Join(s, " ")
+}
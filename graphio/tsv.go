@@ -0,0 +1,58 @@
+package graphio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadTSV reads an edge list with one edge per line.  The first field of a
+// line is the label of a vertex in U and the second the label of a vertex in
+// V.  Fields are separated by tabs, or by white space when a line has no tab,
+// and any further fields are ignored.  Blank lines and lines starting with '#'
+// are skipped.
+func ReadTSV(r io.Reader) (*Graph, error) {
+	raw := newRawGraph()
+	// The sides are separate so the same label may name a vertex of each.
+	vertex := func(label string, side int) int {
+		v := raw.node(fmt.Sprintf("%d:%s", side, label))
+		raw.labels[v] = label
+		raw.side[v] = side
+		return v
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var fields []string
+		if strings.Contains(text, "\t") {
+			fields = strings.Split(text, "\t")
+		} else {
+			fields = strings.Fields(text)
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: an edge needs 2 vertices", line)
+		}
+		raw.edge(vertex(fields[0], 0), vertex(fields[1], 1))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return raw.build()
+}
+
+// WriteTSV writes the edges of g with the label of the vertex in U followed by
+// the label of the vertex in V.
+func WriteTSV(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	g.edges(func(u, v int) {
+		fmt.Fprintf(bw, "%s\t%s\n", g.Label(u), g.Label(v))
+	})
+	return bw.Flush()
+}
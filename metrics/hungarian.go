@@ -0,0 +1,91 @@
+package metrics
+
+import "math"
+
+// hungarian solves the assignment problem on the n by m weight matrix w,
+// returning the assignment of each row to a column that maximizes the total
+// weight.  Rows that are left unassigned, when there are more rows than
+// columns, are assigned -1.
+func hungarian(w [][]float64) []int {
+	n := len(w)
+	if n == 0 {
+		return nil
+	}
+	m := len(w[0])
+	// Pad the matrix to be square and turn it into a cost to be minimized.
+	size := n
+	if m > size {
+		size = m
+	}
+	var max float64
+	for _, row := range w {
+		for _, x := range row {
+			max = math.Max(max, x)
+		}
+	}
+	cost := func(i, j int) float64 {
+		if i < n && j < m {
+			return max - w[i][j]
+		}
+		return max
+	}
+
+	// Potentials u and v, the row matched to each column p and the previous
+	// column on the augmenting path way, all indexed from 1 with 0 a sentinel.
+	u := make([]float64, size+1)
+	v := make([]float64, size+1)
+	p := make([]int, size+1)
+	way := make([]int, size+1)
+	for i := 1; i <= size; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, size+1)
+		used := make([]bool, size+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= size; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost(i0-1, j-1) - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= size; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		// Flip the matching along the augmenting path.
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := make([]int, n)
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for j := 1; j <= size; j++ {
+		if i := p[j] - 1; i < n && j-1 < m {
+			assignment[i] = j - 1
+		}
+	}
+	return assignment
+}
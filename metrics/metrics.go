@@ -0,0 +1,178 @@
+// Package metrics scores biclusters found by bimax against each other or
+// against a planted ground truth.  A bicluster is treated as the set of cells
+// (row, column) covered by its rows and columns, except by 'Recovery' and
+// 'Relevance' which compare only the rows as Prelić et al. (2006) do.
+package metrics
+
+import (
+	"github.com/maxsei/bimax"
+)
+
+// overlap returns the number of vertices shared by x and y.
+func overlap(x, y *bimax.SetOp) (shared int) {
+	if y.Card() < x.Card() {
+		x, y = y, x
+	}
+	x.Each(func(v int) (_ bool) {
+		if y.Has(v) {
+			shared++
+		}
+		return
+	})
+	return
+}
+
+func area(a *bimax.BiMaxResult) int { return a.Rows.Card() * a.Cols.Card() }
+
+// intersection returns the number of cells covered by both a and b.
+func intersection(a, b *bimax.BiMaxResult) int {
+	return overlap(a.Rows, b.Rows) * overlap(a.Cols, b.Cols)
+}
+
+// Jaccard returns the number of cells covered by both a and b over the number
+// of cells covered by either.
+func Jaccard(a, b *bimax.BiMaxResult) float64 {
+	shared := intersection(a, b)
+	union := area(a) + area(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// F1 returns the harmonic mean of the precision and recall of the cells of
+// found with respect to the cells of truth.
+func F1(found, truth *bimax.BiMaxResult) float64 {
+	total := area(found) + area(truth)
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(intersection(found, truth)) / float64(total)
+}
+
+// rowJaccard returns the number of rows in both a and b over the number of
+// rows in either.
+func rowJaccard(a, b *bimax.BiMaxResult) float64 {
+	shared := overlap(a.Rows, b.Rows)
+	union := a.Rows.Card() + b.Rows.Card() - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// matchScore is the average, over the biclusters of a, of the best Jaccard
+// index of the rows with any bicluster of b, the gene match score of Prelić et
+// al. (2006).
+func matchScore(a, b []*bimax.BiMaxResult) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	var total float64
+	for _, x := range a {
+		var best float64
+		for _, y := range b {
+			if j := rowJaccard(x, y); best < j {
+				best = j
+			}
+		}
+		total += best
+	}
+	return total / float64(len(a))
+}
+
+// Recovery measures how well the biclusters in truth were found.  It is the
+// average over truth of the best Jaccard index of the rows with any of found.
+func Recovery(found, truth []*bimax.BiMaxResult) float64 {
+	return matchScore(truth, found)
+}
+
+// Relevance measures how well the biclusters in found represent truth.  It is
+// the average over found of the best Jaccard index of the rows with any of
+// truth.
+func Relevance(found, truth []*bimax.BiMaxResult) float64 {
+	return matchScore(found, truth)
+}
+
+// Consensus pairs each bicluster in a with at most one bicluster in b so that
+// the total Jaccard index of the pairs is greatest and returns the total over
+// the size of the larger set as defined by Hochreiter et al. (2010).
+func Consensus(a, b []*bimax.BiMaxResult) float64 {
+	size := len(a)
+	if len(b) > size {
+		size = len(b)
+	}
+	if size == 0 {
+		return 0
+	}
+	total := assign(a, b, Jaccard)
+	return total / float64(size)
+}
+
+// ClusteringError returns the clustering error of Patrikainen and Meila
+// (2006).  The cells of a and b are paired as in 'Consensus' but by the number
+// of shared cells, and the error is the fraction of the union of cells that is
+// left unpaired.  Cells covered by several biclusters count once per
+// bicluster.
+func ClusteringError(a, b []*bimax.BiMaxResult) float64 {
+	union := unionCells(a, b)
+	if union == 0 {
+		return 0
+	}
+	shared := assign(a, b, func(x, y *bimax.BiMaxResult) float64 {
+		return float64(intersection(x, y))
+	})
+	return (float64(union) - shared) / float64(union)
+}
+
+// assign returns the greatest total similarity of a one to one pairing of a
+// and b.
+func assign(a, b []*bimax.BiMaxResult, similarity func(x, y *bimax.BiMaxResult) float64) (total float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	w := make([][]float64, len(a))
+	for i, x := range a {
+		w[i] = make([]float64, len(b))
+		for j, y := range b {
+			w[i][j] = similarity(x, y)
+		}
+	}
+	for i, j := range hungarian(w) {
+		if j >= 0 {
+			total += w[i][j]
+		}
+	}
+	return
+}
+
+// unionCells returns the size of the union of the cells in a and b where a
+// cell covered k times in a and l times in b counts max(k, l) times.
+func unionCells(a, b []*bimax.BiMaxResult) (size int) {
+	count := func(results []*bimax.BiMaxResult) map[[2]int]int {
+		cells := make(map[[2]int]int)
+		for _, r := range results {
+			r.Rows.Each(func(u int) (_ bool) {
+				r.Cols.Each(func(v int) (_ bool) {
+					cells[[2]int{u, v}]++
+					return
+				})
+				return
+			})
+		}
+		return cells
+	}
+	ca, cb := count(a), count(b)
+	for cell, k := range ca {
+		if l := cb[cell]; l > k {
+			k = l
+		}
+		size += k
+	}
+	for cell, l := range cb {
+		if _, ok := ca[cell]; !ok {
+			size += l
+		}
+	}
+	return
+}
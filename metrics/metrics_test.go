@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/maxsei/bimax"
+)
+
+func bicluster(rows, cols []int) *bimax.BiMaxResult {
+	return &bimax.BiMaxResult{Rows: bimax.NewSetWith(rows...).SetOp, Cols: bimax.NewSetWith(cols...).SetOp}
+}
+
+func near(x, y float64) bool { return math.Abs(x-y) < 1e-9 }
+
+// bestAssignment returns the greatest total weight of assigning the rows of w
+// to distinct columns by trying every assignment.
+func bestAssignment(w [][]float64, i int, used []bool) (best float64) {
+	if i == len(w) {
+		return 0
+	}
+	// Row i may be left unassigned when there are more rows than columns.
+	if len(w) > len(used) {
+		best = bestAssignment(w, i+1, used)
+	}
+	for j := range used {
+		if !used[j] {
+			used[j] = true
+			best = math.Max(best, w[i][j]+bestAssignment(w, i+1, used))
+			used[j] = false
+		}
+	}
+	return
+}
+
+func TestHungarian(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n, m := 1+rng.Intn(5), 1+rng.Intn(5)
+		w := make([][]float64, n)
+		for i := range w {
+			w[i] = make([]float64, m)
+			for j := range w[i] {
+				w[i][j] = float64(rng.Intn(10))
+			}
+		}
+		assignment := hungarian(w)
+		var total float64
+		seen := make(map[int]bool)
+		assigned := 0
+		for i, j := range assignment {
+			if j < 0 {
+				continue
+			}
+			if seen[j] {
+				t.Fatalf("%v: column %d assigned twice in %v", w, j, assignment)
+			}
+			seen[j] = true
+			assigned++
+			total += w[i][j]
+		}
+		if want := min(n, m); assigned != want {
+			t.Fatalf("%v: assigned %d rows, want %d", w, assigned, want)
+		}
+		if want := bestAssignment(w, 0, make([]bool, m)); total != want {
+			t.Fatalf("%v: got total %v with %v, want %v", w, total, assignment, want)
+		}
+	}
+}
+
+func TestPairScores(t *testing.T) {
+	a := bicluster([]int{0, 1}, []int{5, 6})
+	b := bicluster([]int{1, 2}, []int{6, 7, 8})
+	// a and b share the single cell (1, 6) of their 4 + 6 cells.
+	if got := Jaccard(a, b); !near(got, 1.0/9) {
+		t.Errorf("Jaccard: got %v, want 1/9", got)
+	}
+	if got := F1(a, b); !near(got, 2.0/10) {
+		t.Errorf("F1: got %v, want 1/5", got)
+	}
+	if got := Jaccard(a, a); got != 1 {
+		t.Errorf("Jaccard of a bicluster with itself: got %v", got)
+	}
+	empty := bicluster(nil, nil)
+	if Jaccard(empty, empty) != 0 || F1(empty, empty) != 0 {
+		t.Error("scores of empty biclusters are not 0")
+	}
+}
+
+func TestMatchScores(t *testing.T) {
+	truth := []*bimax.BiMaxResult{
+		bicluster([]int{0, 1, 2}, []int{10, 11}),
+		bicluster([]int{5, 6}, []int{12}),
+	}
+	// The rows of the first match 2 of 3 rows of the first of truth however
+	// different their columns are.
+	found := []*bimax.BiMaxResult{bicluster([]int{0, 1}, []int{13, 14})}
+	if got := Recovery(found, truth); !near(got, (2.0/3+0)/2) {
+		t.Errorf("Recovery: got %v, want 1/3", got)
+	}
+	if got := Relevance(found, truth); !near(got, 2.0/3) {
+		t.Errorf("Relevance: got %v, want 2/3", got)
+	}
+	if Recovery(nil, truth) != 0 || Relevance(nil, truth) != 0 {
+		t.Error("scores of no biclusters are not 0")
+	}
+}
+
+func TestConsensus(t *testing.T) {
+	a := []*bimax.BiMaxResult{
+		bicluster([]int{0, 1}, []int{5, 6}),
+		bicluster([]int{2, 3}, []int{7, 8}),
+	}
+	b := []*bimax.BiMaxResult{a[1], a[0]}
+	if got := Consensus(a, b); got != 1 {
+		t.Errorf("Consensus of a reordering: got %v, want 1", got)
+	}
+	if got := ClusteringError(a, b); got != 0 {
+		t.Errorf("ClusteringError of a reordering: got %v, want 0", got)
+	}
+
+	// c matches half of the cells of the first of a and none of the second.
+	c := []*bimax.BiMaxResult{bicluster([]int{0}, []int{5, 6})}
+	if got := Consensus(a, c); !near(got, 0.5/2) {
+		t.Errorf("Consensus: got %v, want 1/4", got)
+	}
+	// Of the 8 cells of a, 2 are paired with c.
+	if got := ClusteringError(a, c); !near(got, 6.0/8) {
+		t.Errorf("ClusteringError: got %v, want 3/4", got)
+	}
+	if Consensus(nil, nil) != 0 || ClusteringError(nil, nil) != 0 {
+		t.Error("scores of no biclusters are not 0")
+	}
+}
@@ -0,0 +1,260 @@
+// Package mtx reads and writes sparse matrices in the Matrix Market coordinate
+// format.  A matrix is loaded straight into the bipartite graph used by bimax
+// where rows are the vertices [0, n) and columns the vertices [n, n+m).
+package mtx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maxsei/bimax"
+	"github.com/yourbasic/graph"
+)
+
+// Fields of a matrix supported by this package.
+const (
+	Pattern = "pattern"
+	Real    = "real"
+	Integer = "integer"
+)
+
+// Matrix is a sparse matrix of Rows by Cols made up of its non-zero entries.
+type Matrix struct {
+	Rows, Cols int
+	// Field is one of Pattern, Real or Integer.  The value of every entry of a
+	// pattern matrix is 1.
+	Field   string
+	Entries []Entry
+}
+
+// Entry is the value at row I and column J of a matrix, counted from 0.
+type Entry struct {
+	I, J  int
+	Value float64
+}
+
+// maxVertices bounds the number of rows and columns of a matrix together, as
+// its graph has a vertex for each of them whether or not it has entries.
+const maxVertices = 1 << 26
+
+// Read reads a coordinate matrix with general or symmetric symmetry.  The
+// entries of a symmetric matrix are mirrored across the diagonal.  Matrices of
+// more than 2²⁶ rows and columns together are rejected.
+func Read(r io.Reader) (*Matrix, error) { return ReadChecked(r, nil) }
+
+// ReadChecked is like 'Read' but first calls check, unless it is nil, with the
+// size given by the header of the matrix.  A matrix too large for the caller
+// is rejected with the error of check before any of its entries are read.
+func ReadChecked(r io.Reader, check func(rows, cols, nnz int) error) (*Matrix, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	next := func() (string, bool) {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || (line > 1 && strings.HasPrefix(text, "%")) {
+				continue
+			}
+			return text, true
+		}
+		return "", false
+	}
+
+	header, ok := next()
+	if !ok {
+		return nil, noInput(scanner)
+	}
+	banner := strings.Fields(strings.ToLower(header))
+	if len(banner) != 5 || banner[0] != "%%matrixmarket" || banner[1] != "matrix" {
+		return nil, fmt.Errorf("line 1: not a Matrix Market matrix header")
+	}
+	if banner[2] != "coordinate" {
+		return nil, fmt.Errorf("line 1: %s matrices are not supported", banner[2])
+	}
+	x := &Matrix{Field: banner[3]}
+	switch x.Field {
+	case Pattern, Real, Integer:
+	default:
+		return nil, fmt.Errorf("line 1: %s fields are not supported", x.Field)
+	}
+	symmetry := banner[4]
+	if symmetry != "general" && symmetry != "symmetric" {
+		return nil, fmt.Errorf("line 1: %s matrices are not supported", symmetry)
+	}
+
+	size, ok := next()
+	if !ok {
+		return nil, noInput(scanner)
+	}
+	var nnz int
+	if _, err := fmt.Sscan(size, &x.Rows, &x.Cols, &nnz); err != nil {
+		return nil, fmt.Errorf("line %d: %v", line, err)
+	}
+	if x.Rows < 0 || x.Cols < 0 || nnz < 0 {
+		return nil, fmt.Errorf("line %d: negative size %d %d %d", line, x.Rows, x.Cols, nnz)
+	}
+	if x.Rows > maxVertices || x.Cols > maxVertices-x.Rows {
+		return nil, fmt.Errorf("line %d: matrix of [%d, %d] has more than %d rows and columns", line, x.Rows, x.Cols, maxVertices)
+	}
+	if nnz > 0 && (x.Rows == 0 || x.Cols < (nnz-1)/x.Rows+1) {
+		return nil, fmt.Errorf("line %d: %d entries do not fit in [%d, %d]", line, nnz, x.Rows, x.Cols)
+	}
+	if symmetry == "symmetric" && x.Rows != x.Cols {
+		return nil, fmt.Errorf("line %d: symmetric matrix of [%d, %d] is not square", line, x.Rows, x.Cols)
+	}
+	if check != nil {
+		if err := check(x.Rows, x.Cols, nnz); err != nil {
+			return nil, err
+		}
+	}
+	// The header is not trusted to size the entries, which grow as they are
+	// read.
+	x.Entries = make([]Entry, 0, min(nnz, 1<<16))
+
+	for k := 0; k < nnz; k++ {
+		text, ok := next()
+		if !ok {
+			if err := noInput(scanner); err != io.ErrUnexpectedEOF {
+				return nil, err
+			}
+			return nil, fmt.Errorf("found %d of %d entries", k, nnz)
+		}
+		fields := strings.Fields(text)
+		want := 3
+		if x.Field == Pattern {
+			want = 2
+		}
+		if len(fields) != want {
+			return nil, fmt.Errorf("line %d: %d fields in a %s entry", line, len(fields), x.Field)
+		}
+		e := Entry{Value: 1}
+		var err error
+		if e.I, err = strconv.Atoi(fields[0]); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if e.J, err = strconv.Atoi(fields[1]); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if e.I < 1 || e.I > x.Rows || e.J < 1 || e.J > x.Cols {
+			return nil, fmt.Errorf("line %d: entry (%d, %d) outside of [%d, %d]", line, e.I, e.J, x.Rows, x.Cols)
+		}
+		e.I, e.J = e.I-1, e.J-1
+		if want == 3 {
+			if e.Value, err = strconv.ParseFloat(fields[2], 64); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+		}
+		x.Entries = append(x.Entries, e)
+		if symmetry == "symmetric" && e.I != e.J {
+			x.Entries = append(x.Entries, Entry{e.J, e.I, e.Value})
+		}
+	}
+	return x, scanner.Err()
+}
+
+func noInput(scanner *bufio.Scanner) error {
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// Write writes x as a general coordinate matrix.
+func Write(w io.Writer, x *Matrix) error {
+	return write(w, x, nil)
+}
+
+func write(w io.Writer, x *Matrix, comments []string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%%%%MatrixMarket matrix coordinate %s general\n", x.Field)
+	for _, c := range comments {
+		fmt.Fprintf(bw, "%% %s\n", c)
+	}
+	fmt.Fprintf(bw, "%d %d %d\n", x.Rows, x.Cols, len(x.Entries))
+	for _, e := range x.Entries {
+		switch x.Field {
+		case Pattern:
+			fmt.Fprintf(bw, "%d %d\n", e.I+1, e.J+1)
+		case Integer:
+			fmt.Fprintf(bw, "%d %d %d\n", e.I+1, e.J+1, int64(e.Value))
+		default:
+			fmt.Fprintf(bw, "%d %d %s\n", e.I+1, e.J+1, strconv.FormatFloat(e.Value, 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
+
+// Graph returns the bipartite graph G of the non-zero entries of x along with
+// its vertex sets U and V.
+func (x *Matrix) Graph() (G *graph.Mutable, U, V *bimax.UnorderedSet) {
+	G = graph.New(x.Rows + x.Cols)
+	U, V = bimax.NewSet(), bimax.NewSet()
+	for _, e := range x.Entries {
+		if e.Value == 0 {
+			continue
+		}
+		u, v := e.I, e.J+x.Rows
+		U.Add(u)
+		V.Add(v)
+		G.AddBoth(u, v)
+	}
+	return
+}
+
+// Objective returns an objective that scores a biclique of the graph of x by
+// the total value of its entries.
+func (x *Matrix) Objective() bimax.Objective {
+	values := make(map[[2]int]float64, len(x.Entries))
+	for _, e := range x.Entries {
+		values[[2]int{e.I, e.J + x.Rows}] += e.Value
+	}
+	return bimax.EdgeWeight(func(u, v int) float64 { return values[[2]int{u, v}] })
+}
+
+// Submatrix returns the entries of x that lie in the rows and columns of the
+// biclique r of the graph of x.  Rows and columns are renumbered in increasing
+// order.
+func (x *Matrix) Submatrix(r *bimax.BiMaxResult) *Matrix {
+	index := func(set *bimax.SetOp, offset int) map[int]int {
+		vv := set.Values()
+		sort.Ints(vv)
+		result := make(map[int]int, len(vv))
+		for i, v := range vv {
+			result[v-offset] = i
+		}
+		return result
+	}
+	rows, cols := index(r.Rows, 0), index(r.Cols, x.Rows)
+	sub := &Matrix{Rows: len(rows), Cols: len(cols), Field: x.Field}
+	for _, e := range x.Entries {
+		i, okI := rows[e.I]
+		j, okJ := cols[e.J]
+		if okI && okJ {
+			sub.Entries = append(sub.Entries, Entry{i, j, e.Value})
+		}
+	}
+	return sub
+}
+
+// WriteBiclique writes the submatrix of x in the biclique r.  The original
+// rows and columns of the submatrix, counted from 1, are written as comments.
+func WriteBiclique(w io.Writer, x *Matrix, r *bimax.BiMaxResult) error {
+	original := func(set *bimax.SetOp, offset int) string {
+		vv := set.Values()
+		sort.Ints(vv)
+		s := make([]string, len(vv))
+		for i, v := range vv {
+			s[i] = strconv.Itoa(v - offset + 1)
+		}
+		return strings.Join(s, " ")
+	}
+	return write(w, x.Submatrix(r), []string{
+		"rows " + original(r.Rows, 0),
+		"cols " + original(r.Cols, x.Rows),
+	})
+}
@@ -0,0 +1,109 @@
+package mtx
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxsei/bimax"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, x := range []*Matrix{
+		{Rows: 2, Cols: 3, Field: Pattern, Entries: []Entry{{0, 0, 1}, {1, 2, 1}}},
+		{Rows: 2, Cols: 2, Field: Real, Entries: []Entry{{0, 1, 0.5}, {1, 0, -2}}},
+		{Rows: 1, Cols: 1, Field: Integer, Entries: []Entry{{0, 0, 7}}},
+		{Rows: 3, Cols: 3, Field: Pattern},
+	} {
+		var b bytes.Buffer
+		if err := Write(&b, x); err != nil {
+			t.Fatal(err)
+		}
+		y, err := Read(&b)
+		if err != nil {
+			t.Fatalf("%v: %v", x, err)
+		}
+		if len(x.Entries) == 0 {
+			x.Entries = []Entry{}
+		}
+		if !reflect.DeepEqual(x, y) {
+			t.Errorf("got %v, want %v", y, x)
+		}
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	x, err := Read(strings.NewReader("%%MatrixMarket matrix coordinate pattern symmetric\n% comment\n2 2 2\n1 1\n2 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}}
+	if !reflect.DeepEqual(x.Entries, want) {
+		t.Errorf("got %v, want %v", x.Entries, want)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	const general = "%%MatrixMarket matrix coordinate pattern general\n"
+	for _, tt := range []struct{ name, input string }{
+		{"empty", ""},
+		{"banner", "%%MatrixMarket matrix array real general\n1 1\n1\n"},
+		{"field", "%%MatrixMarket matrix coordinate complex general\n1 1 0\n"},
+		{"missing size", general},
+		{"negative entries", general + "2 2 -1\n"},
+		{"negative rows", general + "-2 2 0\n"},
+		{"negative cols", general + "2 -2 0\n"},
+		{"too many entries", general + "2 2 5\n"},
+		{"huge entries", general + "2 2 9223372036854775807\n"},
+		{"entries of no rows", general + "0 5 1\n"},
+		{"too many vertices", general + "1000000000 1000000000 0\n"},
+		{"huge rows", general + "9223372036854775807 1 0\n"},
+		{"not square", "%%MatrixMarket matrix coordinate pattern symmetric\n2 3 1\n1 1\n"},
+		{"outside", general + "2 2 1\n3 1\n"},
+		{"fields", general + "2 2 1\n1 1 1\n"},
+		{"few entries", general + "2 2 2\n1 1\n"},
+	} {
+		if x, err := Read(strings.NewReader(tt.input)); err == nil {
+			t.Errorf("%s: got %v and no error", tt.name, x)
+		}
+	}
+}
+
+func TestReadChecked(t *testing.T) {
+	errLarge := errors.New("too large")
+	check := func(rows, cols, nnz int) error {
+		if rows > 10 || cols > 10 || nnz > 10 {
+			return errLarge
+		}
+		return nil
+	}
+	// The entries are never read.
+	input := "%%MatrixMarket matrix coordinate pattern general\n1000000 1000000 1000000\n"
+	if _, err := ReadChecked(strings.NewReader(input), check); err != errLarge {
+		t.Errorf("got %v, want %v", err, errLarge)
+	}
+	input = "%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 2\n"
+	if _, err := ReadChecked(strings.NewReader(input), check); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	x := &Matrix{Rows: 2, Cols: 3, Field: Real, Entries: []Entry{
+		{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {1, 1, 4}, {1, 2, 0},
+	}}
+	G, U, V := x.Graph()
+	r := bimax.BiMax(G, U, V)
+	if !r.Rows.IsEqual(bimax.NewSetWith(0, 1)) || !r.Cols.IsEqual(bimax.NewSetWith(2, 3)) {
+		t.Fatalf("got %v x %v", r.Rows, r.Cols)
+	}
+	if w := x.Objective()(r.Rows, r.Cols); w != 10 {
+		t.Errorf("got weight %v, want 10", w)
+	}
+	sub := x.Submatrix(r)
+	if sub.Rows != 2 || sub.Cols != 2 || len(sub.Entries) != 4 {
+		t.Errorf("got submatrix %v", sub)
+	}
+}
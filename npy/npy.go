@@ -0,0 +1,369 @@
+// Package npy reads and writes NumPy .npy and .npz files so that binary
+// matrices can be shared with Python without conversion.  Boolean, integer and
+// floating point arrays in C or Fortran order can be read.
+package npy
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maxsei/bimax"
+)
+
+var magic = []byte("\x93NUMPY")
+
+// maxBytes is the largest size of the data of an array read by 'Read' once it
+// is decoded into float64s.  The shape of a larger array is rejected before any
+// of its data is read.
+const maxBytes = 1 << 34
+
+// maxSize is the greatest number of elements of an array read by 'Read'.
+const maxSize int64 = maxBytes / 8
+
+// maxHeader is the longest header read, far longer than any header written by
+// NumPy.
+const maxHeader = 1 << 20
+
+// Array is an n-dimensional array stored in C (row major) order.
+type Array struct {
+	Shape []int
+	// Dtype is the NumPy type descriptor of the array such as "|b1", "<u1",
+	// "<i8" or "<f8".
+	Dtype string
+	Data  []float64
+}
+
+var (
+	descrPattern   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	fortranPattern = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	shapePattern   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// Read reads an array from a .npy file.
+func Read(r io.Reader) (*Array, error) {
+	prefix := make([]byte, len(magic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(prefix[:len(magic)], magic) {
+		return nil, fmt.Errorf("not a .npy file")
+	}
+	var headerLen int
+	switch major := prefix[len(magic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		headerLen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		headerLen = int(n)
+	default:
+		return nil, fmt.Errorf(".npy version %d is not supported", major)
+	}
+	if headerLen > maxHeader {
+		return nil, fmt.Errorf(".npy header of %d bytes is too long", headerLen)
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	descr := descrPattern.FindSubmatch(header)
+	fortran := fortranPattern.FindSubmatch(header)
+	shape := shapePattern.FindSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, fmt.Errorf("malformed .npy header %q", header)
+	}
+	a := &Array{Dtype: string(descr[1])}
+	size := int64(1)
+	for _, field := range strings.Split(string(shape[1]), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		d, err := strconv.Atoi(strings.TrimSuffix(field, "L"))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("malformed .npy shape %q", shape[1])
+		}
+		a.Shape = append(a.Shape, d)
+		// The size is checked as it grows so that it cannot overflow.
+		if d > 0 && size > maxSize/int64(d) {
+			return nil, fmt.Errorf(".npy shape %q exceeds the limit of %d elements", shape[1], maxSize)
+		}
+		size *= int64(d)
+	}
+
+	decode, width, order, err := decoder(a.Dtype)
+	if err != nil {
+		return nil, err
+	}
+	if size*int64(width) > math.MaxInt || size*8 > math.MaxInt {
+		return nil, fmt.Errorf(".npy shape %q is too large for this platform", shape[1])
+	}
+	// The data is not allocated up front, so a file that is shorter than its
+	// shape takes no more memory than its length.
+	var buf bytes.Buffer
+	if n, err := io.CopyN(&buf, r, size*int64(width)); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf(".npy data of %d bytes is shorter than its shape %q", n, shape[1])
+		}
+		return nil, err
+	}
+	raw := buf.Bytes()
+	data := make([]float64, size)
+	for i := range data {
+		data[i] = decode(order, raw[i*width:])
+	}
+	if string(fortran[1]) == "True" {
+		data = fortranToC(a.Shape, data)
+	}
+	a.Data = data
+	return a, nil
+}
+
+// decoder returns a function decoding one element of the dtype along with the
+// width of an element in bytes and its byte order.
+func decoder(dtype string) (decode func(binary.ByteOrder, []byte) float64, width int, order binary.ByteOrder, err error) {
+	if len(dtype) < 3 {
+		return nil, 0, nil, fmt.Errorf("dtype %q is not supported", dtype)
+	}
+	switch dtype[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, 0, nil, fmt.Errorf("dtype %q is not supported", dtype)
+	}
+	kind := dtype[1]
+	width, err = strconv.Atoi(dtype[2:])
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("dtype %q is not supported", dtype)
+	}
+	switch {
+	case (kind == 'b' || kind == 'u') && width == 1:
+		decode = func(_ binary.ByteOrder, b []byte) float64 { return float64(b[0]) }
+	case kind == 'i' && width == 1:
+		decode = func(_ binary.ByteOrder, b []byte) float64 { return float64(int8(b[0])) }
+	case kind == 'u' && width == 2:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(o.Uint16(b)) }
+	case kind == 'i' && width == 2:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(int16(o.Uint16(b))) }
+	case kind == 'u' && width == 4:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(o.Uint32(b)) }
+	case kind == 'i' && width == 4:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(int32(o.Uint32(b))) }
+	case kind == 'u' && width == 8:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(o.Uint64(b)) }
+	case kind == 'i' && width == 8:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(int64(o.Uint64(b))) }
+	case kind == 'f' && width == 4:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return float64(math.Float32frombits(o.Uint32(b))) }
+	case kind == 'f' && width == 8:
+		decode = func(o binary.ByteOrder, b []byte) float64 { return math.Float64frombits(o.Uint64(b)) }
+	default:
+		return nil, 0, nil, fmt.Errorf("dtype %q is not supported", dtype)
+	}
+	return
+}
+
+// fortranToC reorders data of the given shape from column major to row major
+// order.
+func fortranToC(shape []int, data []float64) []float64 {
+	result := make([]float64, len(data))
+	index := make([]int, len(shape))
+	for i := range result {
+		// index holds the C order index of element i.
+		f, stride := 0, 1
+		for d := range shape {
+			f += index[d] * stride
+			stride *= shape[d]
+		}
+		result[i] = data[f]
+		for d := len(shape) - 1; d >= 0; d-- {
+			index[d]++
+			if index[d] < shape[d] {
+				break
+			}
+			index[d] = 0
+		}
+	}
+	return result
+}
+
+// ReadNPZ reads every array in a .npz archive by name, without the .npy
+// extension.
+func ReadNPZ(r io.ReaderAt, size int64) (map[string]*Array, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make(map[string]*Array, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		a, err := Read(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", f.Name, err)
+		}
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = a
+	}
+	return arrays, nil
+}
+
+// ReadNPZFile reads every array in the named .npz archive.
+func ReadNPZFile(name string) (map[string]*Array, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return ReadNPZ(bytes.NewReader(b), int64(len(b)))
+}
+
+// Binary returns a 2-dimensional array of 0's and 1's in the form taken by
+// 'bimax.BiMaxBinaryMatrix'.
+func (a *Array) Binary() (n, m int, data []uint8, err error) {
+	if len(a.Shape) != 2 {
+		return 0, 0, nil, fmt.Errorf("array of %d dimensions is not a matrix", len(a.Shape))
+	}
+	n, m = a.Shape[0], a.Shape[1]
+	data = make([]uint8, len(a.Data))
+	for i, x := range a.Data {
+		switch x {
+		case 0:
+		case 1:
+			data[i] = 1
+		default:
+			return 0, 0, nil, fmt.Errorf("value %v at row %d column %d is not a zero or 1", x, i/m, i%m)
+		}
+	}
+	return n, m, data, nil
+}
+
+// Write writes a as a version 1.0 .npy file in C order.  The dtype defaults
+// to "<f8".
+func Write(w io.Writer, a *Array) error {
+	dtype := a.Dtype
+	if dtype == "" {
+		dtype = "<f8"
+	}
+	encode, width, order, err := encoder(dtype)
+	if err != nil {
+		return err
+	}
+	shape := make([]string, len(a.Shape))
+	size := 1
+	for i, d := range a.Shape {
+		shape[i] = strconv.Itoa(d)
+		size *= d
+	}
+	if size != len(a.Data) {
+		return fmt.Errorf("%d values do not fit shape %v", len(a.Data), a.Shape)
+	}
+	tuple := strings.Join(shape, ", ")
+	if len(shape) == 1 {
+		tuple += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", dtype, tuple)
+	// The header is padded with spaces and ends in a newline so that the data
+	// is aligned to 64 bytes.
+	total := len(magic) + 4 + len(header) + 1
+	header += strings.Repeat(" ", (64-total%64)%64) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.Write(magic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+	buf := make([]byte, width)
+	for _, x := range a.Data {
+		encode(order, buf, x)
+		bw.Write(buf)
+	}
+	return bw.Flush()
+}
+
+// encoder returns a function encoding one element of the dtype along with the
+// width of an element in bytes and its byte order.
+func encoder(dtype string) (encode func(binary.ByteOrder, []byte, float64), width int, order binary.ByteOrder, err error) {
+	if _, width, order, err = decoder(dtype); err != nil {
+		return
+	}
+	switch kind := dtype[1]; {
+	case kind == 'b':
+		encode = func(_ binary.ByteOrder, b []byte, x float64) {
+			b[0] = 0
+			if x != 0 {
+				b[0] = 1
+			}
+		}
+	case width == 1:
+		encode = func(_ binary.ByteOrder, b []byte, x float64) { b[0] = byte(int64(x)) }
+	case width == 2:
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint16(b, uint16(int64(x))) }
+	case kind == 'f' && width == 4:
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint32(b, math.Float32bits(float32(x))) }
+	case kind == 'f':
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint64(b, math.Float64bits(x)) }
+	case width == 4:
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint32(b, uint32(int64(x))) }
+	case kind == 'u':
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint64(b, uint64(x)) }
+	default:
+		encode = func(o binary.ByteOrder, b []byte, x float64) { o.PutUint64(b, uint64(int64(x))) }
+	}
+	return
+}
+
+// WriteNPZ writes arrays into an uncompressed .npz archive in order of name.
+func WriteNPZ(w io.Writer, arrays map[string]*Array) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := Write(f, arrays[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Mask returns an n by m boolean array that is true in the cells of the
+// biclique r of an n by m binary matrix, whose columns are numbered [n, n+m)
+// as in the results of 'bimax.BiMaxBinaryMatrix'.
+func Mask(n, m int, r *bimax.BiMaxResult) *Array {
+	a := &Array{Shape: []int{n, m}, Dtype: "|b1", Data: make([]float64, n*m)}
+	r.Rows.Each(func(i int) (_ bool) {
+		r.Cols.Each(func(j int) (_ bool) {
+			a.Data[i*m+j-n] = 1
+			return
+		})
+		return
+	})
+	return a
+}
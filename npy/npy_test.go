@@ -0,0 +1,143 @@
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// file returns a version 1.0 .npy file of a header dictionary and raw data.
+func file(header string, data []byte) []byte {
+	var b bytes.Buffer
+	b.Write(magic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	b.Write(data)
+	return b.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := []float64{0, 1, 1, 0, 1, 1}
+	for _, dtype := range []string{
+		"|b1", "|u1", "|i1", "<u2", "<i2", "<u4", "<i4", "<u8", "<i8", "<f4", "<f8", ">i4", ">f8",
+	} {
+		a := &Array{Shape: []int{2, 3}, Dtype: dtype, Data: data}
+		var b bytes.Buffer
+		if err := Write(&b, a); err != nil {
+			t.Fatalf("%s: %v", dtype, err)
+		}
+		if _, width, _, _ := decoder(dtype); (b.Len()-len(data)*width)%64 != 0 {
+			t.Errorf("%s: data is not aligned to 64 bytes", dtype)
+		}
+		got, err := Read(&b)
+		if err != nil {
+			t.Fatalf("%s: %v", dtype, err)
+		}
+		if !reflect.DeepEqual(got, a) {
+			t.Errorf("%s: got %v, want %v", dtype, got, a)
+		}
+	}
+
+	// Negative values survive a signed dtype.
+	a := &Array{Shape: []int{3}, Dtype: "<i2", Data: []float64{-2, 0, 300}}
+	var b bytes.Buffer
+	if err := Write(&b, a); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Read(&b); err != nil || !reflect.DeepEqual(got.Data, a.Data) {
+		t.Errorf("got %v, %v, want %v", got, err, a.Data)
+	}
+}
+
+func TestFortranOrder(t *testing.T) {
+	// [[1 2 3]
+	//  [4 5 6]] stored column by column.
+	raw := []byte{1, 4, 2, 5, 3, 6}
+	for _, header := range []string{
+		"{'descr': '|u1', 'fortran_order': True, 'shape': (2, 3), }",
+		"{'descr': '|u1', 'fortran_order': False, 'shape': (2, 3), }",
+	} {
+		a, err := Read(bytes.NewReader(file(header, raw)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []float64{1, 2, 3, 4, 5, 6}
+		if strings.Contains(header, "False") {
+			want = []float64{1, 4, 2, 5, 3, 6}
+		}
+		if !reflect.DeepEqual(a.Data, want) {
+			t.Errorf("%s: got %v, want %v", header, a.Data, want)
+		}
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	arrays := map[string]*Array{
+		"x": {Shape: []int{2, 2}, Dtype: "|b1", Data: []float64{1, 0, 0, 1}},
+		"y": {Shape: []int{3}, Dtype: "<f8", Data: []float64{0.5, 1.5, 2.5}},
+	}
+	var b bytes.Buffer
+	if err := WriteNPZ(&b, arrays); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadNPZ(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, arrays) {
+		t.Errorf("got %v, want %v", got, arrays)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	a := &Array{Shape: []int{2, 2}, Data: []float64{1, 0, 1, 1}}
+	n, m, data, err := a.Binary()
+	if err != nil || n != 2 || m != 2 || !reflect.DeepEqual(data, []uint8{1, 0, 1, 1}) {
+		t.Errorf("got [%d, %d]%v, %v", n, m, data, err)
+	}
+	a.Data[1] = 2
+	if _, _, _, err := a.Binary(); err == nil {
+		t.Error("binary matrix holding a 2")
+	}
+	if _, _, _, err := (&Array{Shape: []int{4}, Data: make([]float64, 4)}).Binary(); err == nil {
+		t.Error("binary matrix of 1 dimension")
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	header := func(dtype, shape string) string {
+		return fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", dtype, shape)
+	}
+	for _, tt := range []struct {
+		name string
+		file []byte
+	}{
+		{"empty", nil},
+		{"magic", []byte("\x93NUMPX\x01\x00\x00\x00")},
+		{"version", append(append([]byte{}, magic...), 4, 0, 0, 0)},
+		{"long header", append(append([]byte{}, magic...), 2, 0, 0xff, 0xff, 0xff, 0xff)},
+		{"malformed header", file("{}", nil)},
+		{"negative shape", file(header("|u1", "-1, 2"), nil)},
+		{"dtype", file(header("<c16", "1,"), make([]byte, 16))},
+		{"short data", file(header("<f8", "2, 2"), make([]byte, 31))},
+		{"overflowing shape", file(header("<f8", "4294967296, 4294967296, 4294967296"), nil)},
+		{"large shape", file(header("<f8", "1000000, 1000000"), make([]byte, 8))},
+	} {
+		if a, err := Read(bytes.NewReader(tt.file)); err == nil {
+			t.Errorf("%s: got %v and no error", tt.name, a)
+		}
+	}
+
+	// The limit is on the decoded data, so an array of bytes is rejected when
+	// its float64s would not fit however small the file.
+	for _, dtype := range []string{"<f8", "|b1", "<u1"} {
+		_, err := Read(bytes.NewReader(file(header(dtype, "100000, 50000"), nil)))
+		if err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+			t.Errorf("%s: got error %v, want the limit exceeded", dtype, err)
+		}
+	}
+}
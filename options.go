@@ -0,0 +1,101 @@
+package bimax
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/maxsei/bimax/set"
+	"github.com/yourbasic/graph"
+)
+
+// Options configures which maximal bicliques 'BiMaxAll' returns.
+type Options struct {
+	// Objective scores each biclique.  It defaults to 'Area'.
+	Objective Objective
+	// MinRows and MinCols are the fewest rows and columns a biclique may have.
+	MinRows, MinCols int
+	// Limit is the greatest number of bicliques returned.  Zero returns every
+	// biclique.
+	Limit int
+	// Backend creates the sets of vertices used during the search, which are
+	// also the sets of the results.  It defaults to 'MapBackend'.
+	Backend SetBackend
+}
+
+// SetBackend returns a new empty set of vertices.
+type SetBackend func() Set
+
+// MapBackend returns sets backed by a map, which suit most graphs.
+func MapBackend() Set { return NewSet() }
+
+// RoaringBackend returns sets backed by a roaring bitmap, which are compact and
+// quick to intersect for graphs with millions of vertices.  Vertices must be
+// in the range [0, 2³²).
+func RoaringBackend() Set { return set.NewRoaring() }
+
+// BiMaxAll finds the maximal bicliques of the bipartite graph G of (L ∪ PU,
+// E(G)) that satisfy opts.  Bicliques are in the order of 'SortResults', by
+// non-increasing value of the objective, and only the first opts.Limit
+// bicliques are kept.
+func BiMaxAll(G *graph.Mutable, L, PU *UnorderedSet, opts Options) []*BiMaxResult {
+	results, _ := BiMaxAllContext(context.Background(), G, L, PU, opts)
+	return results
+}
+
+// BiMaxAllContext is like 'BiMaxAll' but stops once ctx is done, returning
+// the bicliques found so far along with the error of ctx.
+func BiMaxAllContext(ctx context.Context, G *graph.Mutable, L, PU *UnorderedSet, opts Options) ([]*BiMaxResult, error) {
+	objective := opts.Objective
+	if objective == nil {
+		objective = Area
+	}
+	rowSet, colSet := L.SetOp, PU.SetOp
+	if opts.Backend != nil {
+		rowSet, colSet = &SetOp{Set: opts.Backend()}, &SetOp{Set: opts.Backend()}
+		rowSet.Update(L.Values()...)
+		colSet.Update(PU.Values()...)
+	}
+	var results []*rankedResult
+	var err error
+	enumerate(G, rowSet, colSet, func(rows, cols *SetOp) (done bool) {
+		if err = ctx.Err(); err != nil {
+			return true
+		}
+		if rows.Card() < opts.MinRows || cols.Card() < opts.MinCols {
+			return
+		}
+		r := &rankedResult{BiMaxResult: &BiMaxResult{Rows: rows, Cols: cols, Weight: objective(rows, cols)}}
+		switch {
+		case opts.Limit <= 0:
+			results = append(results, r)
+		case len(results) < opts.Limit:
+			heap.Push((*worstFirst)(&results), r)
+		case r.less(results[0]):
+			// The last of a full list is replaced by a biclique before it.
+			results[0] = r
+			heap.Fix((*worstFirst)(&results), 0)
+		}
+		return
+	})
+	sortRanked(results)
+	product := make([]*BiMaxResult, len(results))
+	for i, r := range results {
+		product[i] = r.BiMaxResult
+	}
+	return product, err
+}
+
+// worstFirst is a heap of the bicliques kept under a limit with the one that
+// comes last in the order of 'SortResults' at the top.
+type worstFirst []*rankedResult
+
+func (h worstFirst) Len() int           { return len(h) }
+func (h worstFirst) Less(i, j int) bool { return h[j].less(h[i]) }
+func (h worstFirst) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *worstFirst) Push(x any)        { *h = append(*h, x.(*rankedResult)) }
+func (h *worstFirst) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
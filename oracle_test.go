@@ -0,0 +1,61 @@
+package bimax_test
+
+import "math/bits"
+
+// biclique is a biclique of a small binary matrix given as bit masks of its
+// rows and columns.
+type biclique struct {
+	rows, cols uint64
+}
+
+func (b biclique) area() int { return bits.OnesCount64(b.rows) * bits.OnesCount64(b.cols) }
+
+// bruteForce enumerates every maximal biclique of the n by m binary matrix in
+// data, with at least one row and one column, by closing every subset of
+// columns.  It is only meant for matrices with a few columns.
+func bruteForce(n, m int, data []uint8) []biclique {
+	if n > 64 || m > 20 {
+		panic("matrix is too large to be enumerated")
+	}
+	// The neighborhood of each row and column as a bit mask.
+	rowNeighbors := make([]uint64, n)
+	colNeighbors := make([]uint64, m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			if data[i*m+j] == 1 {
+				rowNeighbors[i] |= 1 << uint(j)
+				colNeighbors[j] |= 1 << uint(i)
+			}
+		}
+	}
+	seen := make(map[biclique]bool)
+	var result []biclique
+	for subset := uint64(1); subset < 1<<uint(m); subset++ {
+		// Rows adjacent to every column in the subset.
+		rows := uint64(1)<<uint(n) - 1
+		if n == 64 {
+			rows = ^uint64(0)
+		}
+		for j := 0; j < m; j++ {
+			if subset&(1<<uint(j)) != 0 {
+				rows &= colNeighbors[j]
+			}
+		}
+		if rows == 0 {
+			continue
+		}
+		// Columns adjacent to every one of those rows.
+		cols := uint64(1)<<uint(m) - 1
+		for i := 0; i < n; i++ {
+			if rows&(1<<uint(i)) != 0 {
+				cols &= rowNeighbors[i]
+			}
+		}
+		b := biclique{rows, cols}
+		if !seen[b] {
+			seen[b] = true
+			result = append(result, b)
+		}
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+"""Maximal biclique enumeration of binary matrices.
+
+A thin wrapper of libbimax, the C interface of the Go package
+github.com/maxsei/bimax.  Matrices are numpy arrays, scipy.sparse matrices or
+pandas DataFrames whose nonzero entries are the edges of a bipartite graph
+between rows and columns:
+
+    >>> import numpy as np, bimax
+    >>> x = np.array([[1, 1, 0], [1, 1, 1], [0, 1, 1]])
+    >>> bimax.find(x)
+    [Bicluster(rows=array([0, 1]), cols=array([0, 1]), weight=4.0)]
+
+The library is loaded from $BIMAX_LIBRARY, from beside this package or from
+the system library path.
+"""
+
+import ctypes
+from typing import NamedTuple
+
+import numpy as np
+
+from . import _lib
+from ._lib import BiMaxError
+
+__all__ = ["Bicluster", "BiMaxError", "find", "for_each", "to_frame"]
+
+_MODES = {
+    "largest": _lib.MODE_LARGEST,
+    "top-k": _lib.MODE_TOP_K,
+    "enumerate": _lib.MODE_ENUMERATE,
+}
+_OBJECTIVES = {
+    "area": _lib.OBJECTIVE_AREA,
+    "vertices": _lib.OBJECTIVE_VERTICES,
+}
+
+
+class Bicluster(NamedTuple):
+    """A maximal biclique.  rows and cols are numpy index arrays in increasing
+    order, or pandas Indexes of the labels of a DataFrame."""
+
+    rows: object
+    cols: object
+    weight: float
+
+
+class _Graph:
+    """Owns a bimax_graph handle."""
+
+    def __init__(self, matrix):
+        self.handle = _lib.Graph()
+        self.row_labels = self.col_labels = None
+        if _is_dataframe(matrix):
+            self.row_labels, self.col_labels = matrix.index, matrix.columns
+            matrix = matrix.to_numpy()
+        if _is_sparse(matrix):
+            self._from_sparse(matrix)
+        else:
+            self._from_dense(np.asarray(matrix))
+
+    def _from_dense(self, x):
+        if x.ndim != 2:
+            raise ValueError("matrix must be 2 dimensional, not %d" % x.ndim)
+        if x.dtype == np.bool_:
+            x = x.view(np.uint8)
+        elif x.dtype != np.uint8:
+            if not np.isin(x, (0, 1)).all():
+                raise ValueError("matrix must only hold zeros and ones")
+            x = x.astype(np.uint8)
+        # The matrix is read in place through its strides so that sliced and
+        # Fortran ordered arrays are not copied.
+        _lib.check(_lib.lib.bimax_graph_from_matrix(
+            ctypes.byref(self.handle), x.ctypes.data_as(ctypes.c_void_p),
+            x.shape[0], x.shape[1], x.strides[0], x.strides[1]))
+
+    def _from_sparse(self, x):
+        x = x.tocoo()
+        data = np.asarray(x.data)
+        if not np.isin(data, (0, 1)).all():
+            raise ValueError("matrix must only hold zeros and ones")
+        keep = data != 0
+        u = np.ascontiguousarray(x.row[keep], dtype=np.int64)
+        v = np.ascontiguousarray(x.col[keep], dtype=np.int64)
+        _lib.check(_lib.lib.bimax_graph_new(ctypes.byref(self.handle)))
+        try:
+            _lib.check(_lib.lib.bimax_graph_add_edges(
+                self.handle, u.ctypes.data_as(_lib.Int64Array),
+                v.ctypes.data_as(_lib.Int64Array), len(u)))
+        except BaseException:
+            self.close()
+            raise
+
+    def label(self, rows, cols):
+        """Returns rows and cols as labels if the matrix was labelled."""
+        if self.row_labels is not None:
+            rows, cols = self.row_labels[rows], self.col_labels[cols]
+        return rows, cols
+
+    def close(self):
+        if self.handle:
+            _lib.lib.bimax_graph_destroy(self.handle)
+            self.handle = _lib.Graph()
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        self.close()
+
+
+def _is_sparse(x):
+    try:
+        import scipy.sparse
+    except ImportError:
+        return False
+    return scipy.sparse.issparse(x)
+
+
+def _is_dataframe(x):
+    try:
+        import pandas
+    except ImportError:
+        return False
+    return isinstance(x, pandas.DataFrame)
+
+
+def _options(mode="largest", k=None, objective="area", min_rows=0, min_cols=0):
+    if mode not in _MODES:
+        raise ValueError("unknown mode %r" % mode)
+    if objective not in _OBJECTIVES:
+        raise ValueError("unknown objective %r" % objective)
+    if mode == "top-k" and not k:
+        raise ValueError("mode top-k needs k")
+    return _lib.Options(_MODES[mode], _OBJECTIVES[objective], min_rows, min_cols, k or 0)
+
+
+def _array(ptr, n):
+    if n == 0:
+        return np.empty(0, dtype=np.int64)
+    return np.ctypeslib.as_array(ptr, shape=(n,)).copy()
+
+
+def find(matrix, mode="largest", k=None, objective="area", min_rows=0, min_cols=0):
+    """Returns the maximal bicliques of matrix as a list of Bicluster ordered by
+    non-increasing weight.
+
+    mode is "largest" for the biclique of greatest weight, "top-k" for the k
+    bicliques of greatest weight or "enumerate" for every maximal biclique.
+    objective is "area", rows times columns, or "vertices", rows plus columns.
+    """
+    opts = _options(mode, k, objective, min_rows, min_cols)
+    with _Graph(matrix) as g:
+        result = _lib.Result()
+        _lib.check(_lib.lib.bimax_run(g.handle, ctypes.byref(opts), ctypes.byref(result)))
+        try:
+            found = []
+            values, n = _lib.Int64Array(), ctypes.c_size_t()
+            weight = ctypes.c_double()
+            for i in range(_lib.lib.bimax_result_count(result)):
+                _lib.check(_lib.lib.bimax_result_rows(result, i, ctypes.byref(values), ctypes.byref(n)))
+                rows = _array(values, n.value)
+                _lib.check(_lib.lib.bimax_result_cols(result, i, ctypes.byref(values), ctypes.byref(n)))
+                cols = _array(values, n.value)
+                _lib.check(_lib.lib.bimax_result_weight(result, i, ctypes.byref(weight)))
+                found.append(Bicluster(*g.label(rows, cols), weight.value))
+            return found
+        finally:
+            _lib.lib.bimax_result_destroy(result)
+
+
+def for_each(matrix, fn, min_rows=0, min_cols=0):
+    """Calls fn(rows, cols) with each maximal biclique of matrix as it is found,
+    without collecting them, until fn returns True."""
+    opts = _options(min_rows=min_rows, min_cols=min_cols)
+    raised = []
+
+    def callback(rows, n_rows, cols, n_cols, _):
+        try:
+            return 1 if fn(*g.label(_array(rows, n_rows), _array(cols, n_cols))) else 0
+        except BaseException as e:
+            # Exceptions cannot cross the library so they stop the enumeration
+            # and are raised once it returns.
+            raised.append(e)
+            return 1
+
+    with _Graph(matrix) as g:
+        _lib.check(_lib.lib.bimax_enumerate(g.handle, ctypes.byref(opts), _lib.Callback(callback), None))
+    if raised:
+        raise raised[0]
+
+
+def to_frame(biclusters):
+    """Returns biclusters as a pandas DataFrame with a row per bicluster."""
+    import pandas
+
+    return pandas.DataFrame(
+        {
+            "rows": [list(b.rows) for b in biclusters],
+            "cols": [list(b.cols) for b in biclusters],
+            "n_rows": [len(b.rows) for b in biclusters],
+            "n_cols": [len(b.cols) for b in biclusters],
+            "weight": [b.weight for b in biclusters],
+        }
+    )
@@ -0,0 +1,93 @@
+"""ctypes declarations of the C interface in c/bimax.h."""
+
+import ctypes
+import ctypes.util
+import os
+
+OK = 0
+ERR_INVALID = 1
+ERR_INTERNAL = 2
+
+MODE_LARGEST = 0
+MODE_TOP_K = 1
+MODE_ENUMERATE = 2
+
+OBJECTIVE_AREA = 0
+OBJECTIVE_VERTICES = 1
+
+
+class Options(ctypes.Structure):
+    _fields_ = [
+        ("mode", ctypes.c_int),
+        ("objective", ctypes.c_int),
+        ("min_rows", ctypes.c_int64),
+        ("min_cols", ctypes.c_int64),
+        ("k", ctypes.c_int64),
+    ]
+
+
+Graph = ctypes.c_void_p
+Result = ctypes.c_void_p
+Int64Array = ctypes.POINTER(ctypes.c_int64)
+
+Callback = ctypes.CFUNCTYPE(
+    ctypes.c_int, Int64Array, ctypes.c_size_t, Int64Array, ctypes.c_size_t, ctypes.c_void_p
+)
+
+
+def _find_library():
+    """Locates libbimax, from $BIMAX_LIBRARY, beside this package or on the
+    system library path."""
+    path = os.environ.get("BIMAX_LIBRARY")
+    if path:
+        return path
+    here = os.path.dirname(os.path.abspath(__file__))
+    for name in ("libbimax.so", "libbimax.dylib", "bimax.dll"):
+        if os.path.exists(os.path.join(here, name)):
+            return os.path.join(here, name)
+    path = ctypes.util.find_library("bimax")
+    if path is None:
+        raise OSError("libbimax not found, build it with `make shared` and set BIMAX_LIBRARY")
+    return path
+
+
+def _declare(lib):
+    def fn(name, restype, *argtypes):
+        f = getattr(lib, name)
+        f.restype = restype
+        f.argtypes = argtypes
+
+    c_int, c_size_t, c_int64 = ctypes.c_int, ctypes.c_size_t, ctypes.c_int64
+    fn("bimax_last_error", ctypes.c_char_p)
+    fn("bimax_graph_new", c_int, ctypes.POINTER(Graph))
+    fn("bimax_graph_add_edge", c_int, Graph, c_int64, c_int64)
+    fn("bimax_graph_add_edges", c_int, Graph, Int64Array, Int64Array, c_size_t)
+    fn("bimax_graph_from_matrix", c_int, ctypes.POINTER(Graph), ctypes.c_void_p,
+       c_int64, c_int64, c_int64, c_int64)
+    fn("bimax_graph_destroy", None, Graph)
+    fn("bimax_run", c_int, Graph, ctypes.POINTER(Options), ctypes.POINTER(Result))
+    fn("bimax_enumerate", c_int, Graph, ctypes.POINTER(Options), Callback, ctypes.c_void_p)
+    fn("bimax_result_count", c_size_t, Result)
+    fn("bimax_result_rows", c_int, Result, c_size_t, ctypes.POINTER(Int64Array), ctypes.POINTER(c_size_t))
+    fn("bimax_result_cols", c_int, Result, c_size_t, ctypes.POINTER(Int64Array), ctypes.POINTER(c_size_t))
+    fn("bimax_result_weight", c_int, Result, c_size_t, ctypes.POINTER(ctypes.c_double))
+    fn("bimax_result_destroy", None, Result)
+    return lib
+
+
+lib = _declare(ctypes.CDLL(_find_library()))
+
+
+class BiMaxError(Exception):
+    """An error reported by libbimax."""
+
+    def __init__(self, status, message):
+        super().__init__(message)
+        self.status = status
+
+
+def check(status):
+    """Raises the last error of libbimax unless status is OK."""
+    if status != OK:
+        message = lib.bimax_last_error()
+        raise BiMaxError(status, message.decode() if message else "status %d" % status)
@@ -0,0 +1,108 @@
+"""Tests of the bimax package against a locally built libbimax.
+
+The library is taken from $BIMAX_LIBRARY, or built with the go tool from the
+repository when it is not set.
+"""
+
+import os
+import shutil
+import subprocess
+import sys
+import tempfile
+import unittest
+
+HERE = os.path.dirname(os.path.abspath(__file__))
+ROOT = os.path.dirname(os.path.dirname(HERE))
+
+if "BIMAX_LIBRARY" not in os.environ and shutil.which("go"):
+    lib = os.path.join(tempfile.mkdtemp(), "libbimax.so")
+    subprocess.run(["go", "build", "-buildmode=c-shared", "-o", lib, "./c"], cwd=ROOT, check=True)
+    os.environ["BIMAX_LIBRARY"] = lib
+sys.path.insert(0, os.path.dirname(HERE))
+
+try:
+    import numpy as np
+except ImportError:
+    np = None
+
+
+def optional(name):
+    try:
+        return __import__(name)
+    except ImportError:
+        return None
+
+
+scipy = optional("scipy.sparse") and optional("scipy")
+pandas = optional("pandas")
+
+MATRIX = [
+    [1, 1, 0],
+    [1, 1, 1],
+    [0, 1, 1],
+]
+
+
+@unittest.skipIf(np is None, "numpy is not installed")
+class TestFind(unittest.TestCase):
+    def setUp(self):
+        global bimax
+        import bimax
+
+    def test_largest(self):
+        (b,) = bimax.find(np.array(MATRIX))
+        self.assertEqual(b.weight, 4)
+        self.assertEqual(len(b.rows) * len(b.cols), 4)
+
+    def test_top_k(self):
+        found = bimax.find(np.array(MATRIX, dtype=bool), mode="top-k", k=3)
+        self.assertEqual([b.weight for b in found], [4, 4, 3])
+
+    def test_enumerate(self):
+        found = bimax.find(np.array(MATRIX), mode="enumerate")
+        self.assertEqual(len(found), 4)
+
+    def test_strides(self):
+        x = np.array(MATRIX, dtype=np.uint8)
+        want = bimax.find(x, mode="enumerate")
+        for y in (np.asfortranarray(x), x.T.copy().T, x[::-1][::-1]):
+            self.assertEqual(str(bimax.find(y, mode="enumerate")), str(want))
+        # A slice is read in place.
+        wide = np.zeros((3, 6), dtype=np.uint8)
+        wide[:, ::2] = x
+        got = bimax.find(wide[:, ::2], mode="enumerate")
+        self.assertEqual(str(got), str(want))
+
+    def test_invalid(self):
+        with self.assertRaises(ValueError):
+            bimax.find(np.array([[2, 1]]))
+        with self.assertRaises(bimax.BiMaxError):
+            bimax.find(np.zeros((0, 3)))
+
+    def test_for_each(self):
+        seen = []
+        bimax.for_each(np.array(MATRIX), lambda rows, cols: seen.append((rows, cols)))
+        self.assertEqual(len(seen), 4)
+        seen = []
+        bimax.for_each(np.array(MATRIX), lambda rows, cols: seen.append(rows) or len(seen) == 2)
+        self.assertEqual(len(seen), 2)
+        with self.assertRaises(ZeroDivisionError):
+            bimax.for_each(np.array(MATRIX), lambda rows, cols: 1 / 0)
+
+    @unittest.skipIf(not scipy, "scipy is not installed")
+    def test_sparse(self):
+        import scipy.sparse
+
+        x = scipy.sparse.csr_matrix(np.array(MATRIX))
+        self.assertEqual(str(bimax.find(x, mode="enumerate")), str(bimax.find(np.array(MATRIX), mode="enumerate")))
+
+    @unittest.skipIf(pandas is None, "pandas is not installed")
+    def test_dataframe(self):
+        x = pandas.DataFrame(MATRIX, index=["x", "y", "z"], columns=["a", "b", "c"])
+        (b,) = bimax.find(x)
+        self.assertEqual(list(b.rows) + list(b.cols), ["x", "y", "a", "b"])
+        self.assertEqual(list(bimax.to_frame([b])["n_rows"]), [2])
+
+
+if __name__ == "__main__":
+    unittest.main()
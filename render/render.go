@@ -0,0 +1,291 @@
+// Package render draws binary matrices as heatmaps, reordered so that the
+// biclusters found by bimax sit along the diagonal from the top left, with
+// each bicluster outlined in its own color.
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+
+	"github.com/maxsei/bimax"
+)
+
+// Palette colors the outlines of biclusters in turn.
+var Palette = []color.RGBA{
+	{0xe4, 0x1a, 0x1c, 0xff},
+	{0x37, 0x7e, 0xb8, 0xff},
+	{0x4d, 0xaf, 0x4a, 0xff},
+	{0x98, 0x4e, 0xa3, 0xff},
+	{0xff, 0x7f, 0x00, 0xff},
+	{0xa6, 0x56, 0x28, 0xff},
+	{0xf7, 0x81, 0xbf, 0xff},
+	{0x99, 0x99, 0x99, 0xff},
+}
+
+// Options configures how a matrix is drawn.
+type Options struct {
+	// Cell is the size in pixels of a cell of the matrix.  It defaults to the
+	// largest size, up to 16, that fits the matrix into MaxWidth by
+	// MaxHeight.
+	Cell int
+	// MaxWidth and MaxHeight bound the size of the heatmap, without labels.
+	// Matrices with more rows or columns are downsampled so that each cell
+	// shows the fraction of 1's in a block of the matrix.  They default to
+	// 1000.
+	MaxWidth, MaxHeight int
+	// RowLabels and ColLabels label the axes.  Labels are only drawn when
+	// the matrix is not downsampled.
+	RowLabels, ColLabels []string
+}
+
+// layout is a matrix reordered, downsampled and placed on the canvas.
+type layout struct {
+	// gh and gw are the number of cells after downsampling, each of which
+	// covers by rows and bx columns of the matrix.
+	gh, gw, by, bx int
+	cell           int
+	// value is the fraction of 1's in each cell.
+	value []float64
+	// left and top are the space taken by labels.
+	left, top int
+	// rowLabels and colLabels are the labels of the cells in order.
+	rowLabels, colLabels []string
+	// boxes are the outlines of each bicluster in cells.
+	boxes [][]image.Rectangle
+}
+
+func (l *layout) width() int  { return l.left + l.gw*l.cell }
+func (l *layout) height() int { return l.top + l.gh*l.cell }
+
+// newLayout lays out the n by m matrix data for drawing.  The measure of a
+// label is its length in pixels for a cell of the given size.
+func newLayout(n, m int, data []uint8, results bimax.BiMaxResults, opts Options, measure func(label string, cell int) int) (*layout, error) {
+	if len(data) != n*m {
+		return nil, fmt.Errorf("matrix data cannot be reshaped into [%d, %d]", n, m)
+	}
+	if n == 0 || m == 0 {
+		return nil, fmt.Errorf("matrix [%d, %d] is empty", n, m)
+	}
+	if (opts.RowLabels != nil && len(opts.RowLabels) != n) || (opts.ColLabels != nil && len(opts.ColLabels) != m) {
+		return nil, fmt.Errorf("%d row labels and %d column labels for a matrix of [%d, %d]",
+			len(opts.RowLabels), len(opts.ColLabels), n, m)
+	}
+	if opts.MaxWidth <= 0 {
+		opts.MaxWidth = 1000
+	}
+	if opts.MaxHeight <= 0 {
+		opts.MaxHeight = 1000
+	}
+
+	l := &layout{by: 1, bx: 1, cell: opts.Cell}
+	if l.cell <= 0 {
+		l.cell = 16
+		if c := opts.MaxWidth / m; c < l.cell {
+			l.cell = c
+		}
+		if c := opts.MaxHeight / n; c < l.cell {
+			l.cell = c
+		}
+		if l.cell < 1 {
+			l.cell = 1
+		}
+	}
+	// Downsample into blocks when a cell would not fit.
+	if n*l.cell > opts.MaxHeight {
+		l.by = (n*l.cell + opts.MaxHeight - 1) / opts.MaxHeight
+	}
+	if m*l.cell > opts.MaxWidth {
+		l.bx = (m*l.cell + opts.MaxWidth - 1) / opts.MaxWidth
+	}
+	l.gh, l.gw = (n+l.by-1)/l.by, (m+l.bx-1)/l.bx
+
+	rows, cols := results.Permutation(n, m)
+	l.value = make([]float64, l.gh*l.gw)
+	count := make([]int, l.gh*l.gw)
+	for y, i := range rows {
+		for x, j := range cols {
+			k := (y/l.by)*l.gw + x/l.bx
+			l.value[k] += float64(data[i*m+j])
+			count[k]++
+		}
+	}
+	for k := range l.value {
+		l.value[k] /= float64(count[k])
+	}
+
+	if l.by == 1 && l.bx == 1 {
+		if opts.RowLabels != nil {
+			for _, i := range rows {
+				l.rowLabels = append(l.rowLabels, opts.RowLabels[i])
+				if w := measure(opts.RowLabels[i], l.cell) + 4; w > l.left {
+					l.left = w
+				}
+			}
+		}
+		if opts.ColLabels != nil {
+			for _, j := range cols {
+				l.colLabels = append(l.colLabels, opts.ColLabels[j])
+				if w := measure(opts.ColLabels[j], l.cell) + 4; w > l.top {
+					l.top = w
+				}
+			}
+		}
+	}
+
+	// Position of each row and column in the reordered matrix.
+	rowPos, colPos := make([]int, n), make([]int, m)
+	for y, i := range rows {
+		rowPos[i] = y
+	}
+	for x, j := range cols {
+		colPos[j] = x
+	}
+	for _, r := range results {
+		var ys, xs []int
+		r.Rows.Each(func(i int) (_ bool) {
+			ys = append(ys, rowPos[i]/l.by)
+			return
+		})
+		r.Cols.Each(func(j int) (_ bool) {
+			xs = append(xs, colPos[j-n]/l.bx)
+			return
+		})
+		// A bicluster overlapping an earlier one may be split into several
+		// boxes.
+		var boxes []image.Rectangle
+		for _, ry := range runs(ys) {
+			for _, rx := range runs(xs) {
+				boxes = append(boxes, image.Rect(rx[0], ry[0], rx[1], ry[1]))
+			}
+		}
+		l.boxes = append(l.boxes, boxes)
+	}
+	return l, nil
+}
+
+// runs returns the half open ranges of consecutive values in vv.
+func runs(vv []int) (result [][2]int) {
+	sort.Ints(vv)
+	for i, v := range vv {
+		if i > 0 && v <= vv[i-1]+1 {
+			result[len(result)-1][1] = v + 1
+			continue
+		}
+		result = append(result, [2]int{v, v + 1})
+	}
+	return
+}
+
+// shade returns the gray of a cell with the given fraction of 1's.
+func shade(value float64) color.Gray {
+	return color.Gray{Y: uint8(255 - value*255 + 0.5)}
+}
+
+// PNG draws the n by m binary matrix data reordered by the permutation of
+// results, with each of results outlined, as a PNG image.
+func PNG(w io.Writer, n, m int, data []uint8, results bimax.BiMaxResults, opts Options) error {
+	l, err := newLayout(n, m, data, results, opts, func(label string, cell int) int {
+		// The glyphs do not fit into smaller cells.
+		if cell < glyphHeight+1 {
+			return 0
+		}
+		return textWidth(label)
+	})
+	if err != nil {
+		return err
+	}
+	img := image.NewRGBA(image.Rect(0, 0, l.width(), l.height()))
+	fill := func(r image.Rectangle, c color.Color) {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				img.Set(x, y, c)
+			}
+		}
+	}
+	fill(img.Bounds(), color.White)
+	for k, v := range l.value {
+		x, y := l.left+(k%l.gw)*l.cell, l.top+(k/l.gw)*l.cell
+		fill(image.Rect(x, y, x+l.cell, y+l.cell), shade(v))
+	}
+	if l.cell >= glyphHeight+1 {
+		pad := (l.cell - glyphHeight) / 2
+		for y, label := range l.rowLabels {
+			drawText(img, l.left-2-textWidth(label), l.top+y*l.cell+pad, label, color.Black, false)
+		}
+		for x, label := range l.colLabels {
+			drawText(img, l.left+x*l.cell+pad, l.top-3, label, color.Black, true)
+		}
+	}
+	// Outlines are drawn inside the edge of each box.
+	thickness := 2
+	if l.cell < 4 {
+		thickness = 1
+	}
+	for k, boxes := range l.boxes {
+		c := Palette[k%len(Palette)]
+		for _, b := range boxes {
+			r := image.Rect(l.left+b.Min.X*l.cell, l.top+b.Min.Y*l.cell, l.left+b.Max.X*l.cell, l.top+b.Max.Y*l.cell)
+			fill(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+thickness), c)
+			fill(image.Rect(r.Min.X, r.Max.Y-thickness, r.Max.X, r.Max.Y), c)
+			fill(image.Rect(r.Min.X, r.Min.Y, r.Min.X+thickness, r.Max.Y), c)
+			fill(image.Rect(r.Max.X-thickness, r.Min.Y, r.Max.X, r.Max.Y), c)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// SVG draws the n by m binary matrix data reordered by the permutation of
+// results, with each of results outlined, as an SVG image.
+func SVG(w io.Writer, n, m int, data []uint8, results bimax.BiMaxResults, opts Options) error {
+	// Labels are drawn in a monospace font of 0.8 times the cell size, the
+	// width of whose glyphs is about 0.6 times the font size.
+	l, err := newLayout(n, m, data, results, opts, func(label string, cell int) int {
+		return int(float64(len(label)) * 0.48 * float64(cell))
+	})
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		l.width(), l.height(), l.width(), l.height())
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="#fff"/>`+"\n", l.width(), l.height())
+	for k, v := range l.value {
+		if v == 0 {
+			continue
+		}
+		g := shade(v).Y
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"/>`+"\n",
+			l.left+(k%l.gw)*l.cell, l.top+(k/l.gw)*l.cell, l.cell, l.cell, g, g, g)
+	}
+	size := 0.8 * float64(l.cell)
+	for y, label := range l.rowLabels {
+		fmt.Fprintf(bw, `<text x="%d" y="%g" font-family="monospace" font-size="%g" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n",
+			l.left-2, float64(l.top)+(float64(y)+0.5)*float64(l.cell), size, html.EscapeString(label))
+	}
+	for x, label := range l.colLabels {
+		cx := float64(l.left) + (float64(x)+0.5)*float64(l.cell)
+		fmt.Fprintf(bw, `<text transform="translate(%g %d) rotate(-90)" font-family="monospace" font-size="%g" dominant-baseline="middle">%s</text>`+"\n",
+			cx, l.top-2, size, html.EscapeString(label))
+	}
+	// Outlines are drawn inside the edge of each box.
+	inset, stroke := 1, 2
+	if l.cell < 4 {
+		inset, stroke = 0, 1
+	}
+	for k, boxes := range l.boxes {
+		c := Palette[k%len(Palette)]
+		for _, b := range boxes {
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="#%02x%02x%02x" stroke-width="%d"><title>bicluster %d</title></rect>`+"\n",
+				l.left+b.Min.X*l.cell+inset, l.top+b.Min.Y*l.cell+inset, b.Dx()*l.cell-2*inset, b.Dy()*l.cell-2*inset,
+				c.R, c.G, c.B, stroke, k+1)
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
@@ -0,0 +1,197 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxsei/bimax"
+)
+
+func TestRuns(t *testing.T) {
+	for _, tc := range []struct {
+		vv   []int
+		want [][2]int
+	}{
+		{nil, nil},
+		{[]int{3}, [][2]int{{3, 4}}},
+		{[]int{2, 0, 1}, [][2]int{{0, 3}}},
+		{[]int{5, 1, 2, 7, 6}, [][2]int{{1, 3}, {5, 8}}},
+		// Downsampled positions repeat.
+		{[]int{4, 4, 0, 0, 1}, [][2]int{{0, 2}, {4, 5}}},
+	} {
+		if got := runs(append([]int(nil), tc.vv...)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("runs(%v): got %v, want %v", tc.vv, got, tc.want)
+		}
+	}
+}
+
+// length measures labels as one pixel per byte per pixel of cell.
+func length(label string, cell int) int { return len(label) * cell }
+
+func TestLayoutCell(t *testing.T) {
+	data := make([]uint8, 4*8)
+	for _, tc := range []struct {
+		opts Options
+		want int
+	}{
+		{Options{}, 16},
+		{Options{Cell: 3}, 3},
+		{Options{MaxWidth: 64, MaxHeight: 64}, 8},
+		{Options{MaxWidth: 64, MaxHeight: 12}, 3},
+	} {
+		l, err := newLayout(4, 8, data, nil, tc.opts, length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.cell != tc.want || l.by != 1 || l.bx != 1 {
+			t.Errorf("%+v: got cell %d and blocks [%d, %d], want cell %d", tc.opts, l.cell, l.by, l.bx, tc.want)
+		}
+		if l.width() != 8*l.cell || l.height() != 4*l.cell {
+			t.Errorf("%+v: got size [%d, %d]", tc.opts, l.width(), l.height())
+		}
+	}
+}
+
+func TestLayoutDownsample(t *testing.T) {
+	const n, m = 100, 30
+	// Rows alternate between all 1's and all 0's.
+	data := make([]uint8, n*m)
+	for i := 0; i < n; i += 2 {
+		for j := 0; j < m; j++ {
+			data[i*m+j] = 1
+		}
+	}
+	labels := make([]string, n)
+	opts := Options{MaxWidth: 20, MaxHeight: 40, RowLabels: labels}
+	l, err := newLayout(n, m, data, nil, opts, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cells of one pixel cover blocks of 3 by 2 rounded up to fit.
+	if l.cell != 1 || l.by != 3 || l.bx != 2 || l.gh != 34 || l.gw != 15 {
+		t.Fatalf("got cell %d, blocks [%d, %d] and grid [%d, %d]", l.cell, l.by, l.bx, l.gh, l.gw)
+	}
+	for k, v := range l.value {
+		// A block of 3 rows has 2 or 1 rows of 1's in turn and the last
+		// block only has the 100th row, of 0's.
+		y := k / l.gw
+		want := []float64{2.0 / 3, 1.0 / 3}[y%2]
+		if y == l.gh-1 {
+			want = 0
+		}
+		if v != want {
+			t.Fatalf("cell %d: got %v, want %v", k, v, want)
+		}
+	}
+	if l.rowLabels != nil || l.left != 0 {
+		t.Error("labels of a downsampled matrix are drawn")
+	}
+}
+
+func TestLayoutLabels(t *testing.T) {
+	data := []uint8{
+		0, 1, 1,
+		1, 0, 0,
+	}
+	results := bimax.BiMaxResults{{Rows: bimax.NewSetWith(1).SetOp, Cols: bimax.NewSetWith(2).SetOp}}
+	opts := Options{Cell: 2, RowLabels: []string{"a", "bbb"}, ColLabels: []string{"x", "yy", "zzzz"}}
+	l, err := newLayout(2, 3, data, results, opts, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Labels follow the permutation and the space for them fits the longest
+	// with a margin of 4.
+	if !reflect.DeepEqual(l.rowLabels, []string{"bbb", "a"}) || !reflect.DeepEqual(l.colLabels, []string{"x", "yy", "zzzz"}) {
+		t.Errorf("got row labels %v and column labels %v", l.rowLabels, l.colLabels)
+	}
+	if l.left != 3*2+4 || l.top != 4*2+4 {
+		t.Errorf("got left %d and top %d, want 10 and 12", l.left, l.top)
+	}
+	if l.width() != l.left+3*2 || l.height() != l.top+2*2 {
+		t.Errorf("got size [%d, %d]", l.width(), l.height())
+	}
+	if want := [][]image.Rectangle{{image.Rect(0, 0, 1, 1)}}; !reflect.DeepEqual(l.boxes, want) {
+		t.Errorf("got boxes %v, want %v", l.boxes, want)
+	}
+}
+
+func TestLayoutBoxes(t *testing.T) {
+	data := make([]uint8, 4*4)
+	results := bimax.BiMaxResults{
+		{Rows: bimax.NewSetWith(0, 1, 2).SetOp, Cols: bimax.NewSetWith(4, 5).SetOp},
+		{Rows: bimax.NewSetWith(1, 3).SetOp, Cols: bimax.NewSetWith(5, 6, 7).SetOp},
+	}
+	l, err := newLayout(4, 4, data, results, Options{}, length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The overlap of the bicliques is contiguous, so each is a single box.
+	want := [][]image.Rectangle{
+		{image.Rect(0, 0, 2, 3)},
+		{image.Rect(1, 2, 4, 4)},
+	}
+	if !reflect.DeepEqual(l.boxes, want) {
+		t.Errorf("got boxes %v, want %v", l.boxes, want)
+	}
+}
+
+func TestLayoutErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n, m int
+		data []uint8
+		opts Options
+	}{
+		{"shape", 2, 2, make([]uint8, 3), Options{}},
+		{"empty", 0, 2, nil, Options{}},
+		{"row labels", 2, 2, make([]uint8, 4), Options{RowLabels: []string{"a"}}},
+		{"column labels", 2, 2, make([]uint8, 4), Options{ColLabels: []string{"a", "b", "c"}}},
+	} {
+		if _, err := newLayout(tc.n, tc.m, tc.data, nil, tc.opts, length); err == nil {
+			t.Errorf("%s: no error", tc.name)
+		}
+	}
+}
+
+func TestDraw(t *testing.T) {
+	data := []uint8{
+		1, 1, 0,
+		1, 1, 0,
+		0, 0, 1,
+	}
+	results := bimax.BiMaxResults{
+		{Rows: bimax.NewSetWith(0, 1).SetOp, Cols: bimax.NewSetWith(3, 4).SetOp},
+		{Rows: bimax.NewSetWith(2).SetOp, Cols: bimax.NewSetWith(5).SetOp},
+	}
+	opts := Options{Cell: 10, RowLabels: []string{"r0", "r1", "r2"}}
+
+	var b bytes.Buffer
+	if err := PNG(&b, 3, 3, data, results, opts); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := img.Bounds().Size(), image.Pt(textWidth("r0")+4+30, 30); got != want {
+		t.Errorf("PNG: got size %v, want %v", got, want)
+	}
+
+	b.Reset()
+	if err := SVG(&b, 3, 3, data, results, opts); err != nil {
+		t.Fatal(err)
+	}
+	svg := b.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("SVG is not a single svg element:\n%s", svg)
+	}
+	for _, s := range []string{"<title>bicluster 1</title>", "<title>bicluster 2</title>", ">r2</text>"} {
+		if !strings.Contains(svg, s) {
+			t.Errorf("SVG does not contain %q", s)
+		}
+	}
+}
@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: bimax.proto
+
+package bimaxpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Objective scores a biclique.
+type Objective int32
+
+const (
+	// The number of rows times the number of columns.
+	Objective_OBJECTIVE_AREA Objective = 0
+	// The number of rows plus the number of columns.
+	Objective_OBJECTIVE_VERTICES Objective = 1
+	// The total weight of the edges, the values of the matrix.
+	Objective_OBJECTIVE_WEIGHT Objective = 2
+)
+
+// Enum value maps for Objective.
+var (
+	Objective_name = map[int32]string{
+		0: "OBJECTIVE_AREA",
+		1: "OBJECTIVE_VERTICES",
+		2: "OBJECTIVE_WEIGHT",
+	}
+	Objective_value = map[string]int32{
+		"OBJECTIVE_AREA":     0,
+		"OBJECTIVE_VERTICES": 1,
+		"OBJECTIVE_WEIGHT":   2,
+	}
+)
+
+func (x Objective) Enum() *Objective {
+	p := new(Objective)
+	*p = x
+	return p
+}
+
+func (x Objective) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Objective) Descriptor() protoreflect.EnumDescriptor {
+	return file_bimax_proto_enumTypes[0].Descriptor()
+}
+
+func (Objective) Type() protoreflect.EnumType {
+	return &file_bimax_proto_enumTypes[0]
+}
+
+func (x Objective) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Objective.Descriptor instead.
+func (Objective) EnumDescriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{0}
+}
+
+type Options struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Objective Objective              `protobuf:"varint,1,opt,name=objective,proto3,enum=bimax.v1.Objective" json:"objective,omitempty"`
+	// The fewest rows and columns of a biclique.
+	MinRows int32 `protobuf:"varint,2,opt,name=min_rows,json=minRows,proto3" json:"min_rows,omitempty"`
+	MinCols int32 `protobuf:"varint,3,opt,name=min_cols,json=minCols,proto3" json:"min_cols,omitempty"`
+	// The greatest number of bicliques returned, zero for every biclique.
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	mi := &file_bimax_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Options) GetObjective() Objective {
+	if x != nil {
+		return x.Objective
+	}
+	return Objective_OBJECTIVE_AREA
+}
+
+func (x *Options) GetMinRows() int32 {
+	if x != nil {
+		return x.MinRows
+	}
+	return 0
+}
+
+func (x *Options) GetMinCols() int32 {
+	if x != nil {
+		return x.MinCols
+	}
+	return 0
+}
+
+func (x *Options) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Matrix is an n by m matrix in row major order.  Every non-zero value is an
+// edge between a row and a column.  Values other than 0 and 1 are only allowed
+// for the weight objective and must not be negative.
+type Matrix struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          int32                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols          int32                  `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+	Values        []float64              `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Matrix) Reset() {
+	*x = Matrix{}
+	mi := &file_bimax_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Matrix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Matrix) ProtoMessage() {}
+
+func (x *Matrix) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Matrix.ProtoReflect.Descriptor instead.
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Matrix) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *Matrix) GetCols() int32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+func (x *Matrix) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Edges is a bipartite graph whose edges join row u[i] and column v[i].  Rows
+// and columns are numbered independently.
+type Edges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	U             []int64                `protobuf:"varint,1,rep,packed,name=u,proto3" json:"u,omitempty"`
+	V             []int64                `protobuf:"varint,2,rep,packed,name=v,proto3" json:"v,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edges) Reset() {
+	*x = Edges{}
+	mi := &file_bimax_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edges) ProtoMessage() {}
+
+func (x *Edges) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edges.ProtoReflect.Descriptor instead.
+func (*Edges) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Edges) GetU() []int64 {
+	if x != nil {
+		return x.U
+	}
+	return nil
+}
+
+func (x *Edges) GetV() []int64 {
+	if x != nil {
+		return x.V
+	}
+	return nil
+}
+
+type Problem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Graph:
+	//
+	//	*Problem_Matrix
+	//	*Problem_Edges
+	Graph         isProblem_Graph `protobuf_oneof:"graph"`
+	Options       *Options        `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Problem) Reset() {
+	*x = Problem{}
+	mi := &file_bimax_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Problem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Problem) ProtoMessage() {}
+
+func (x *Problem) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Problem.ProtoReflect.Descriptor instead.
+func (*Problem) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Problem) GetGraph() isProblem_Graph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *Problem) GetMatrix() *Matrix {
+	if x != nil {
+		if x, ok := x.Graph.(*Problem_Matrix); ok {
+			return x.Matrix
+		}
+	}
+	return nil
+}
+
+func (x *Problem) GetEdges() *Edges {
+	if x != nil {
+		if x, ok := x.Graph.(*Problem_Edges); ok {
+			return x.Edges
+		}
+	}
+	return nil
+}
+
+func (x *Problem) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type isProblem_Graph interface {
+	isProblem_Graph()
+}
+
+type Problem_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,1,opt,name=matrix,proto3,oneof"`
+}
+
+type Problem_Edges struct {
+	Edges *Edges `protobuf:"bytes,2,opt,name=edges,proto3,oneof"`
+}
+
+func (*Problem_Matrix) isProblem_Graph() {}
+
+func (*Problem_Edges) isProblem_Graph() {}
+
+// Biclique is a maximal biclique.  For a matrix the rows and columns are
+// indices from 0 and for edges they are the vertices of u and v.
+type Biclique struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []int64                `protobuf:"varint,1,rep,packed,name=rows,proto3" json:"rows,omitempty"`
+	Cols          []int64                `protobuf:"varint,2,rep,packed,name=cols,proto3" json:"cols,omitempty"`
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Biclique) Reset() {
+	*x = Biclique{}
+	mi := &file_bimax_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Biclique) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Biclique) ProtoMessage() {}
+
+func (x *Biclique) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Biclique.ProtoReflect.Descriptor instead.
+func (*Biclique) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Biclique) GetRows() []int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *Biclique) GetCols() []int64 {
+	if x != nil {
+		return x.Cols
+	}
+	return nil
+}
+
+func (x *Biclique) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Bicliques struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bicliques     []*Biclique            `protobuf:"bytes,1,rep,name=bicliques,proto3" json:"bicliques,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bicliques) Reset() {
+	*x = Bicliques{}
+	mi := &file_bimax_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bicliques) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bicliques) ProtoMessage() {}
+
+func (x *Bicliques) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bicliques.ProtoReflect.Descriptor instead.
+func (*Bicliques) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Bicliques) GetBicliques() []*Biclique {
+	if x != nil {
+		return x.Bicliques
+	}
+	return nil
+}
+
+// MatrixChunk is part of an uploaded matrix.  The first chunk gives the shape
+// and the options, and the values of every chunk are appended in row major
+// order.
+type MatrixChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          int32                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols          int32                  `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+	Options       *Options               `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	Values        []float64              `protobuf:"fixed64,4,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatrixChunk) Reset() {
+	*x = MatrixChunk{}
+	mi := &file_bimax_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatrixChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixChunk) ProtoMessage() {}
+
+func (x *MatrixChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_bimax_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixChunk.ProtoReflect.Descriptor instead.
+func (*MatrixChunk) Descriptor() ([]byte, []int) {
+	return file_bimax_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MatrixChunk) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *MatrixChunk) GetCols() int32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+func (x *MatrixChunk) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *MatrixChunk) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_bimax_proto protoreflect.FileDescriptor
+
+const file_bimax_proto_rawDesc = "" +
+	"\n" +
+	"\vbimax.proto\x12\bbimax.v1\"\x88\x01\n" +
+	"\aOptions\x121\n" +
+	"\tobjective\x18\x01 \x01(\x0e2\x13.bimax.v1.ObjectiveR\tobjective\x12\x19\n" +
+	"\bmin_rows\x18\x02 \x01(\x05R\aminRows\x12\x19\n" +
+	"\bmin_cols\x18\x03 \x01(\x05R\aminCols\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"H\n" +
+	"\x06Matrix\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x05R\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x01(\x05R\x04cols\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\x01R\x06values\"#\n" +
+	"\x05Edges\x12\f\n" +
+	"\x01u\x18\x01 \x03(\x03R\x01u\x12\f\n" +
+	"\x01v\x18\x02 \x03(\x03R\x01v\"\x94\x01\n" +
+	"\aProblem\x12*\n" +
+	"\x06matrix\x18\x01 \x01(\v2\x10.bimax.v1.MatrixH\x00R\x06matrix\x12'\n" +
+	"\x05edges\x18\x02 \x01(\v2\x0f.bimax.v1.EdgesH\x00R\x05edges\x12+\n" +
+	"\aoptions\x18\x03 \x01(\v2\x11.bimax.v1.OptionsR\aoptionsB\a\n" +
+	"\x05graph\"J\n" +
+	"\bBiclique\x12\x12\n" +
+	"\x04rows\x18\x01 \x03(\x03R\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x03(\x03R\x04cols\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\"=\n" +
+	"\tBicliques\x120\n" +
+	"\tbicliques\x18\x01 \x03(\v2\x12.bimax.v1.BicliqueR\tbicliques\"z\n" +
+	"\vMatrixChunk\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x05R\x04rows\x12\x12\n" +
+	"\x04cols\x18\x02 \x01(\x05R\x04cols\x12+\n" +
+	"\aoptions\x18\x03 \x01(\v2\x11.bimax.v1.OptionsR\aoptions\x12\x16\n" +
+	"\x06values\x18\x04 \x03(\x01R\x06values*M\n" +
+	"\tObjective\x12\x12\n" +
+	"\x0eOBJECTIVE_AREA\x10\x00\x12\x16\n" +
+	"\x12OBJECTIVE_VERTICES\x10\x01\x12\x14\n" +
+	"\x10OBJECTIVE_WEIGHT\x10\x022\xb1\x01\n" +
+	"\x05BiMax\x124\n" +
+	"\vFindLargest\x12\x11.bimax.v1.Problem\x1a\x12.bimax.v1.Biclique\x124\n" +
+	"\tEnumerate\x12\x11.bimax.v1.Problem\x1a\x12.bimax.v1.Biclique0\x01\x12<\n" +
+	"\fUploadMatrix\x12\x15.bimax.v1.MatrixChunk\x1a\x13.bimax.v1.Bicliques(\x01B%Z#github.com/maxsei/bimax/rpc/bimaxpbb\x06proto3"
+
+var (
+	file_bimax_proto_rawDescOnce sync.Once
+	file_bimax_proto_rawDescData []byte
+)
+
+func file_bimax_proto_rawDescGZIP() []byte {
+	file_bimax_proto_rawDescOnce.Do(func() {
+		file_bimax_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bimax_proto_rawDesc), len(file_bimax_proto_rawDesc)))
+	})
+	return file_bimax_proto_rawDescData
+}
+
+var file_bimax_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bimax_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_bimax_proto_goTypes = []any{
+	(Objective)(0),      // 0: bimax.v1.Objective
+	(*Options)(nil),     // 1: bimax.v1.Options
+	(*Matrix)(nil),      // 2: bimax.v1.Matrix
+	(*Edges)(nil),       // 3: bimax.v1.Edges
+	(*Problem)(nil),     // 4: bimax.v1.Problem
+	(*Biclique)(nil),    // 5: bimax.v1.Biclique
+	(*Bicliques)(nil),   // 6: bimax.v1.Bicliques
+	(*MatrixChunk)(nil), // 7: bimax.v1.MatrixChunk
+}
+var file_bimax_proto_depIdxs = []int32{
+	0, // 0: bimax.v1.Options.objective:type_name -> bimax.v1.Objective
+	2, // 1: bimax.v1.Problem.matrix:type_name -> bimax.v1.Matrix
+	3, // 2: bimax.v1.Problem.edges:type_name -> bimax.v1.Edges
+	1, // 3: bimax.v1.Problem.options:type_name -> bimax.v1.Options
+	5, // 4: bimax.v1.Bicliques.bicliques:type_name -> bimax.v1.Biclique
+	1, // 5: bimax.v1.MatrixChunk.options:type_name -> bimax.v1.Options
+	4, // 6: bimax.v1.BiMax.FindLargest:input_type -> bimax.v1.Problem
+	4, // 7: bimax.v1.BiMax.Enumerate:input_type -> bimax.v1.Problem
+	7, // 8: bimax.v1.BiMax.UploadMatrix:input_type -> bimax.v1.MatrixChunk
+	5, // 9: bimax.v1.BiMax.FindLargest:output_type -> bimax.v1.Biclique
+	5, // 10: bimax.v1.BiMax.Enumerate:output_type -> bimax.v1.Biclique
+	6, // 11: bimax.v1.BiMax.UploadMatrix:output_type -> bimax.v1.Bicliques
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_bimax_proto_init() }
+func file_bimax_proto_init() {
+	if File_bimax_proto != nil {
+		return
+	}
+	file_bimax_proto_msgTypes[3].OneofWrappers = []any{
+		(*Problem_Matrix)(nil),
+		(*Problem_Edges)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bimax_proto_rawDesc), len(file_bimax_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bimax_proto_goTypes,
+		DependencyIndexes: file_bimax_proto_depIdxs,
+		EnumInfos:         file_bimax_proto_enumTypes,
+		MessageInfos:      file_bimax_proto_msgTypes,
+	}.Build()
+	File_bimax_proto = out.File
+	file_bimax_proto_goTypes = nil
+	file_bimax_proto_depIdxs = nil
+}
@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: bimax.proto
+
+package bimaxpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BiMax_FindLargest_FullMethodName  = "/bimax.v1.BiMax/FindLargest"
+	BiMax_Enumerate_FullMethodName    = "/bimax.v1.BiMax/Enumerate"
+	BiMax_UploadMatrix_FullMethodName = "/bimax.v1.BiMax/UploadMatrix"
+)
+
+// BiMaxClient is the client API for BiMax service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BiMax finds the maximal bicliques of bipartite graphs.
+type BiMaxClient interface {
+	// FindLargest returns the maximal biclique with the greatest value of the
+	// objective.  The limit of the options is ignored.
+	FindLargest(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Biclique, error)
+	// Enumerate streams each maximal biclique as it is found, in no particular
+	// order, until limit bicliques have been sent.
+	Enumerate(ctx context.Context, in *Problem, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Biclique], error)
+	// UploadMatrix receives a matrix too large for a single message in chunks of
+	// rows and returns its bicliques ordered by non-increasing value of the
+	// objective.
+	UploadMatrix(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[MatrixChunk, Bicliques], error)
+}
+
+type biMaxClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBiMaxClient(cc grpc.ClientConnInterface) BiMaxClient {
+	return &biMaxClient{cc}
+}
+
+func (c *biMaxClient) FindLargest(ctx context.Context, in *Problem, opts ...grpc.CallOption) (*Biclique, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Biclique)
+	err := c.cc.Invoke(ctx, BiMax_FindLargest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biMaxClient) Enumerate(ctx context.Context, in *Problem, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Biclique], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BiMax_ServiceDesc.Streams[0], BiMax_Enumerate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Problem, Biclique]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BiMax_EnumerateClient = grpc.ServerStreamingClient[Biclique]
+
+func (c *biMaxClient) UploadMatrix(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[MatrixChunk, Bicliques], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BiMax_ServiceDesc.Streams[1], BiMax_UploadMatrix_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MatrixChunk, Bicliques]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BiMax_UploadMatrixClient = grpc.ClientStreamingClient[MatrixChunk, Bicliques]
+
+// BiMaxServer is the server API for BiMax service.
+// All implementations must embed UnimplementedBiMaxServer
+// for forward compatibility.
+//
+// BiMax finds the maximal bicliques of bipartite graphs.
+type BiMaxServer interface {
+	// FindLargest returns the maximal biclique with the greatest value of the
+	// objective.  The limit of the options is ignored.
+	FindLargest(context.Context, *Problem) (*Biclique, error)
+	// Enumerate streams each maximal biclique as it is found, in no particular
+	// order, until limit bicliques have been sent.
+	Enumerate(*Problem, grpc.ServerStreamingServer[Biclique]) error
+	// UploadMatrix receives a matrix too large for a single message in chunks of
+	// rows and returns its bicliques ordered by non-increasing value of the
+	// objective.
+	UploadMatrix(grpc.ClientStreamingServer[MatrixChunk, Bicliques]) error
+	mustEmbedUnimplementedBiMaxServer()
+}
+
+// UnimplementedBiMaxServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBiMaxServer struct{}
+
+func (UnimplementedBiMaxServer) FindLargest(context.Context, *Problem) (*Biclique, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindLargest not implemented")
+}
+func (UnimplementedBiMaxServer) Enumerate(*Problem, grpc.ServerStreamingServer[Biclique]) error {
+	return status.Error(codes.Unimplemented, "method Enumerate not implemented")
+}
+func (UnimplementedBiMaxServer) UploadMatrix(grpc.ClientStreamingServer[MatrixChunk, Bicliques]) error {
+	return status.Error(codes.Unimplemented, "method UploadMatrix not implemented")
+}
+func (UnimplementedBiMaxServer) mustEmbedUnimplementedBiMaxServer() {}
+func (UnimplementedBiMaxServer) testEmbeddedByValue()               {}
+
+// UnsafeBiMaxServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BiMaxServer will
+// result in compilation errors.
+type UnsafeBiMaxServer interface {
+	mustEmbedUnimplementedBiMaxServer()
+}
+
+func RegisterBiMaxServer(s grpc.ServiceRegistrar, srv BiMaxServer) {
+	// If the following call panics, it indicates UnimplementedBiMaxServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BiMax_ServiceDesc, srv)
+}
+
+func _BiMax_FindLargest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Problem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiMaxServer).FindLargest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiMax_FindLargest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiMaxServer).FindLargest(ctx, req.(*Problem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiMax_Enumerate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Problem)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BiMaxServer).Enumerate(m, &grpc.GenericServerStream[Problem, Biclique]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BiMax_EnumerateServer = grpc.ServerStreamingServer[Biclique]
+
+func _BiMax_UploadMatrix_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BiMaxServer).UploadMatrix(&grpc.GenericServerStream[MatrixChunk, Bicliques]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BiMax_UploadMatrixServer = grpc.ClientStreamingServer[MatrixChunk, Bicliques]
+
+// BiMax_ServiceDesc is the grpc.ServiceDesc for BiMax service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BiMax_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bimax.v1.BiMax",
+	HandlerType: (*BiMaxServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindLargest",
+			Handler:    _BiMax_FindLargest_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Enumerate",
+			Handler:       _BiMax_Enumerate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadMatrix",
+			Handler:       _BiMax_UploadMatrix_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "bimax.proto",
+}
@@ -0,0 +1,5 @@
+// Package bimaxpb holds the protocol buffer messages and gRPC service of
+// bimax.  Package rpc implements the service.
+package bimaxpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bimax.proto
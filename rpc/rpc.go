@@ -0,0 +1,262 @@
+// Package rpc implements the BiMax gRPC service defined in package bimaxpb.
+//
+//	s := grpc.NewServer()
+//	bimaxpb.RegisterBiMaxServer(s, rpc.New(rpc.Config{}))
+//	s.Serve(lis)
+//
+// Packages rpc and bimaxpb are a module of their own so that users of bimax do
+// not depend on gRPC.  Its go.mod requires a published version of bimax, while
+// rpc/go.work builds it against the library in the same checkout.
+package rpc
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/rpc/bimaxpb"
+	"github.com/yourbasic/graph"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Config limits the problems that a server accepts.  Zero fields take their
+// defaults.
+type Config struct {
+	// MaxCells is the largest number of cells of a matrix, 10⁷ by default.
+	MaxCells int
+	// MaxEdges is the largest number of edges, and bounds the vertices, of an
+	// edge list.  It is 10⁷ by default.
+	MaxEdges int
+}
+
+// Server implements bimaxpb.BiMaxServer.
+type Server struct {
+	bimaxpb.UnimplementedBiMaxServer
+	config Config
+}
+
+// New returns a server with the limits of config.
+func New(config Config) *Server {
+	if config.MaxCells <= 0 {
+		config.MaxCells = 1e7
+	}
+	if config.MaxEdges <= 0 {
+		config.MaxEdges = 1e7
+	}
+	return &Server{config: config}
+}
+
+// problem is a graph ready to be solved.
+type problem struct {
+	G         *graph.Mutable
+	U, V      *bimax.UnorderedSet
+	opts      bimax.Options
+	colOffset int
+}
+
+// newProblem checks a problem against the limits of the server and builds its
+// graph.
+func (s *Server) newProblem(req *bimaxpb.Problem) (*problem, error) {
+	switch g := req.GetGraph().(type) {
+	case *bimaxpb.Problem_Matrix:
+		x := g.Matrix
+		return s.matrixProblem(int(x.GetRows()), int(x.GetCols()), x.GetValues(), req.GetOptions())
+	case *bimaxpb.Problem_Edges:
+		return s.edgesProblem(g.Edges, req.GetOptions())
+	}
+	return nil, status.Error(codes.InvalidArgument, "problem has no graph")
+}
+
+func (s *Server) checkShape(n, m int) error {
+	if n <= 0 || m <= 0 {
+		return status.Errorf(codes.InvalidArgument, "matrix [%d, %d] is empty", n, m)
+	}
+	if m > s.config.MaxCells/n {
+		return status.Errorf(codes.ResourceExhausted, "matrix of [%d, %d] exceeds the limit of %d cells", n, m, s.config.MaxCells)
+	}
+	return nil
+}
+
+func (s *Server) matrixProblem(n, m int, values []float64, opts *bimaxpb.Options) (*problem, error) {
+	if err := s.checkShape(n, m); err != nil {
+		return nil, err
+	}
+	if len(values) != n*m {
+		return nil, status.Errorf(codes.InvalidArgument, "%d values cannot be reshaped into [%d, %d]", len(values), n, m)
+	}
+	weighted := opts.GetObjective() == bimaxpb.Objective_OBJECTIVE_WEIGHT
+	pattern := make([]uint8, len(values))
+	for i, x := range values {
+		if math.IsNaN(x) || x < 0 || (!weighted && x != 0 && x != 1) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v is not a zero or 1, use the weight objective for non-negative weights", x)
+		}
+		if x != 0 {
+			pattern[i] = 1
+		}
+	}
+	p := &problem{colOffset: n}
+	p.G, p.U, p.V = bimax.BinaryMatrixGraph(n, m, pattern)
+	if weighted {
+		p.opts.Objective = bimax.EdgeWeight(func(u, v int) float64 { return values[u*m+v-n] })
+	}
+	return p, p.setOptions(opts)
+}
+
+func (s *Server) edgesProblem(edges *bimaxpb.Edges, opts *bimaxpb.Options) (*problem, error) {
+	uu, vv := edges.GetU(), edges.GetV()
+	if len(uu) != len(vv) {
+		return nil, status.Errorf(codes.InvalidArgument, "len(u): %d len(v): %d must be equal", len(uu), len(vv))
+	}
+	if len(uu) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty edge list")
+	}
+	if len(uu) > s.config.MaxEdges {
+		return nil, status.Errorf(codes.ResourceExhausted, "%d edges exceed the limit of %d", len(uu), s.config.MaxEdges)
+	}
+	if opts.GetObjective() == bimaxpb.Objective_OBJECTIVE_WEIGHT {
+		return nil, status.Error(codes.InvalidArgument, "the weight objective needs a matrix")
+	}
+	u, v := make([]int, len(uu)), make([]int, len(vv))
+	for i := range uu {
+		if uu[i] < 0 || vv[i] < 0 || uu[i] >= int64(s.config.MaxEdges) || vv[i] >= int64(s.config.MaxEdges) {
+			return nil, status.Errorf(codes.InvalidArgument, "edge %d has a vertex out of range [0, %d)", i, s.config.MaxEdges)
+		}
+		u[i], v[i] = int(uu[i]), int(vv[i])
+	}
+	p := &problem{}
+	p.G, p.U, p.V, p.colOffset = bimax.EdgeListGraph(u, v)
+	return p, p.setOptions(opts)
+}
+
+func (p *problem) setOptions(opts *bimaxpb.Options) error {
+	switch opts.GetObjective() {
+	case bimaxpb.Objective_OBJECTIVE_AREA, bimaxpb.Objective_OBJECTIVE_WEIGHT:
+	case bimaxpb.Objective_OBJECTIVE_VERTICES:
+		p.opts.Objective = bimax.VertexWeight(func(int) float64 { return 1 })
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown objective %v", opts.GetObjective())
+	}
+	if opts.GetLimit() < 0 {
+		return status.Errorf(codes.InvalidArgument, "limit %d is negative", opts.GetLimit())
+	}
+	p.opts.MinRows, p.opts.MinCols = int(opts.GetMinRows()), int(opts.GetMinCols())
+	p.opts.Limit = int(opts.GetLimit())
+	return nil
+}
+
+// solve returns the bicliques of p, or the status of ctx once it is done.
+func (p *problem) solve(ctx context.Context) (*bimaxpb.Bicliques, error) {
+	results, err := bimax.BiMaxAllContext(ctx, p.G, p.U, p.V, p.opts)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	resp := &bimaxpb.Bicliques{}
+	for _, r := range results {
+		resp.Bicliques = append(resp.Bicliques, p.biclique(r.Rows, r.Cols, r.Weight))
+	}
+	return resp, nil
+}
+
+// biclique converts a biclique of p so that columns are column indices.
+func (p *problem) biclique(rows, cols *bimax.SetOp, weight float64) *bimaxpb.Biclique {
+	b := &bimaxpb.Biclique{Weight: weight}
+	rows.Each(func(u int) (_ bool) {
+		b.Rows = append(b.Rows, int64(u))
+		return
+	})
+	cols.Each(func(v int) (_ bool) {
+		b.Cols = append(b.Cols, int64(v-p.colOffset))
+		return
+	})
+	sort.Slice(b.Rows, func(i, j int) bool { return b.Rows[i] < b.Rows[j] })
+	sort.Slice(b.Cols, func(i, j int) bool { return b.Cols[i] < b.Cols[j] })
+	return b
+}
+
+// FindLargest returns the maximal biclique with the greatest value of the
+// objective, which is empty when the graph has no biclique satisfying the
+// options.
+func (s *Server) FindLargest(ctx context.Context, req *bimaxpb.Problem) (*bimaxpb.Biclique, error) {
+	p, err := s.newProblem(req)
+	if err != nil {
+		return nil, err
+	}
+	p.opts.Limit = 1
+	resp, err := p.solve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Bicliques) == 0 {
+		return &bimaxpb.Biclique{}, nil
+	}
+	return resp.Bicliques[0], nil
+}
+
+// Enumerate streams each maximal biclique as 'bimax.Enumerate' reports it.
+func (s *Server) Enumerate(req *bimaxpb.Problem, stream grpc.ServerStreamingServer[bimaxpb.Biclique]) error {
+	p, err := s.newProblem(req)
+	if err != nil {
+		return err
+	}
+	objective := p.opts.Objective
+	if objective == nil {
+		objective = bimax.Area
+	}
+	ctx := stream.Context()
+	sent := 0
+	bimax.Enumerate(p.G, p.U, p.V, func(rows, cols *bimax.UnorderedSet) (done bool) {
+		if err = ctx.Err(); err != nil {
+			err = status.FromContextError(err).Err()
+			return true
+		}
+		if rows.Card() < p.opts.MinRows || cols.Card() < p.opts.MinCols {
+			return
+		}
+		if err = stream.Send(p.biclique(rows.SetOp, cols.SetOp, objective(rows.SetOp, cols.SetOp))); err != nil {
+			return true
+		}
+		sent++
+		return p.opts.Limit > 0 && sent == p.opts.Limit
+	})
+	return err
+}
+
+// UploadMatrix assembles a matrix from its chunks and returns its bicliques.
+func (s *Server) UploadMatrix(stream grpc.ClientStreamingServer[bimaxpb.MatrixChunk, bimaxpb.Bicliques]) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no chunks")
+	}
+	if err != nil {
+		return err
+	}
+	n, m := int(first.GetRows()), int(first.GetCols())
+	if err := s.checkShape(n, m); err != nil {
+		return err
+	}
+	values := make([]float64, 0, n*m)
+	for chunk := first; ; {
+		if len(values)+len(chunk.GetValues()) > n*m {
+			return status.Errorf(codes.InvalidArgument, "more than %d values for a matrix of [%d, %d]", n*m, n, m)
+		}
+		values = append(values, chunk.GetValues()...)
+		if chunk, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	p, err := s.matrixProblem(n, m, values, first.GetOptions())
+	if err != nil {
+		return err
+	}
+	resp, err := p.solve(stream.Context())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
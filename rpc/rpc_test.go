@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/maxsei/bimax/rpc/bimaxpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// dial serves s on a local listener and returns a client connected to it.
+func dial(t *testing.T, s *Server) bimaxpb.BiMaxClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	bimaxpb.RegisterBiMaxServer(gs, s)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return bimaxpb.NewBiMaxClient(conn)
+}
+
+var matrix = &bimaxpb.Matrix{Rows: 3, Cols: 3, Values: []float64{
+	1, 1, 0,
+	1, 1, 1,
+	0, 1, 1,
+}}
+
+func TestFindLargest(t *testing.T) {
+	client := dial(t, New(Config{}))
+	b, err := client.FindLargest(context.Background(), &bimaxpb.Problem{Graph: &bimaxpb.Problem_Matrix{Matrix: matrix}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Weight != 4 || len(b.Rows) != 2 || len(b.Cols) != 2 {
+		t.Errorf("got %v × %v of weight %v, want a biclique of area 4", b.Rows, b.Cols, b.Weight)
+	}
+
+	_, err = client.FindLargest(context.Background(), &bimaxpb.Problem{Graph: &bimaxpb.Problem_Matrix{
+		Matrix: &bimaxpb.Matrix{Rows: 1, Cols: 2, Values: []float64{1, 2}},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("non-binary matrix: got %v, want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	client := dial(t, New(Config{}))
+	edges := &bimaxpb.Edges{U: []int64{0, 0, 1, 1, 1, 2, 2}, V: []int64{3, 4, 3, 4, 5, 4, 5}}
+	for _, limit := range []int32{0, 2} {
+		stream, err := client.Enumerate(context.Background(), &bimaxpb.Problem{
+			Graph:   &bimaxpb.Problem_Edges{Edges: edges},
+			Options: &bimaxpb.Options{Limit: limit},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		count := 0
+		for {
+			_, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			count++
+		}
+		// The maximal bicliques are {0, 1} × {3, 4}, {1, 2} × {4, 5},
+		// {0, 1, 2} × {4}, and {1} × {3, 4, 5}.
+		want := 4
+		if limit > 0 {
+			want = int(limit)
+		}
+		if count != want {
+			t.Errorf("limit %d: got %d bicliques, want %d", limit, count, want)
+		}
+	}
+}
+
+func TestEdges(t *testing.T) {
+	client := dial(t, New(Config{MaxEdges: 100}))
+	// Rows and columns are numbered independently, so row 5 may have a column
+	// of a smaller number.
+	b, err := client.FindLargest(context.Background(), &bimaxpb.Problem{Graph: &bimaxpb.Problem_Edges{
+		Edges: &bimaxpb.Edges{U: []int64{5, 5}, V: []int64{1, 0}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Rows) != 1 || b.Rows[0] != 5 || len(b.Cols) != 2 || b.Cols[0] != 0 || b.Cols[1] != 1 {
+		t.Errorf("got %v × %v, want [5] × [0 1]", b.Rows, b.Cols)
+	}
+
+	for _, edges := range []*bimaxpb.Edges{
+		{U: []int64{-1}, V: []int64{0}},
+		{U: []int64{0}, V: []int64{100}},
+		{U: []int64{0, 1}, V: []int64{0}},
+		{},
+	} {
+		_, err := client.FindLargest(context.Background(), &bimaxpb.Problem{Graph: &bimaxpb.Problem_Edges{Edges: edges}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: got %v, want %v", edges, err, codes.InvalidArgument)
+		}
+	}
+}
+
+func TestUploadMatrix(t *testing.T) {
+	client := dial(t, New(Config{}))
+	stream, err := client.UploadMatrix(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunks := []*bimaxpb.MatrixChunk{
+		{Rows: matrix.Rows, Cols: matrix.Cols, Options: &bimaxpb.Options{Limit: 2}, Values: matrix.Values[:3]},
+		{Values: matrix.Values[3:]},
+	}
+	for _, c := range chunks {
+		if err := stream.Send(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Bicliques) != 2 || resp.Bicliques[0].Weight != 4 {
+		t.Errorf("got %v, want 2 bicliques with the first of weight 4", resp.Bicliques)
+	}
+}
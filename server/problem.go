@@ -0,0 +1,239 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/mtx"
+	"github.com/maxsei/bimax/table"
+	"github.com/yourbasic/graph"
+)
+
+// Request is a problem submitted as JSON.  The input is given by Format as
+// either a dense Matrix, a list of Edges or the text of a csv, tsv or Matrix
+// Market file in Data.
+type Request struct {
+	// Format is one of "matrix", "edges", "csv", "tsv" or "mtx".  It defaults
+	// to "matrix".
+	Format string      `json:"format,omitempty"`
+	Matrix [][]float64 `json:"matrix,omitempty"`
+	// Edges join a row to a column.  Rows and columns are numbered
+	// independently.
+	Edges [][2]int `json:"edges,omitempty"`
+	Data  string   `json:"data,omitempty"`
+	// Mode is one of "largest", "top-k" or "enumerate".  It defaults to
+	// "largest".
+	Mode string `json:"mode,omitempty"`
+	// K is the number of bicliques returned in the top-k mode.
+	K       int `json:"k,omitempty"`
+	MinRows int `json:"min_rows,omitempty"`
+	MinCols int `json:"min_cols,omitempty"`
+	// Objective is one of "area", "vertices" or "weight".  It defaults to
+	// "area".
+	Objective string `json:"objective,omitempty"`
+	// TimeoutMS bounds the time taken to solve the problem in milliseconds.
+	TimeoutMS int `json:"timeout_ms,omitempty"`
+}
+
+// Response holds the bicliques found for a problem.  The columns of a matrix
+// are given as column indices [0, m).
+type Response struct {
+	Results   []*bimax.BiMaxResult `json:"results"`
+	RowLabels []string             `json:"row_labels,omitempty"`
+	ColLabels []string             `json:"col_labels,omitempty"`
+}
+
+// requestFromQuery reads the options of a request from URL query parameters
+// with the same names as the JSON fields.
+func requestFromQuery(q url.Values) (*Request, error) {
+	req := &Request{
+		Format:    q.Get("format"),
+		Mode:      q.Get("mode"),
+		Objective: q.Get("objective"),
+	}
+	for name, field := range map[string]*int{
+		"k": &req.K, "min_rows": &req.MinRows, "min_cols": &req.MinCols, "timeout_ms": &req.TimeoutMS,
+	} {
+		if s := q.Get(name); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("query parameter %s: %v", name, err)
+			}
+			*field = v
+		}
+	}
+	return req, nil
+}
+
+// problem is a request ready to be solved.
+type problem struct {
+	G                    *graph.Mutable
+	U, V                 *bimax.UnorderedSet
+	opts                 bimax.Options
+	timeout              time.Duration
+	colOffset            int
+	rowLabels, colLabels []string
+}
+
+// newProblem checks a request against the limits of the server and builds its
+// graph.
+func (s *Server) newProblem(req *Request) (*problem, error) {
+	p := &problem{}
+	var (
+		n, m    int
+		weights []float64
+	)
+	switch req.Format {
+	case "", "matrix":
+		n = len(req.Matrix)
+		if n > 0 {
+			m = len(req.Matrix[0])
+		}
+		if err := s.checkCells(n, m); err != nil {
+			return nil, err
+		}
+		weights = make([]float64, 0, n*m)
+		for i, row := range req.Matrix {
+			if len(row) != m {
+				return nil, fmt.Errorf("row %d has %d entries in a matrix of %d columns", i, len(row), m)
+			}
+			weights = append(weights, row...)
+		}
+	case "csv", "tsv":
+		opts := table.Options{}
+		if req.Format == "tsv" {
+			opts.Comma = '\t'
+		}
+		t, err := table.Read(strings.NewReader(req.Data), opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkCells(t.N, t.M); err != nil {
+			return nil, err
+		}
+		n, m, weights = t.N, t.M, t.Data
+		p.rowLabels, p.colLabels = t.RowLabels, t.ColLabels
+	case "mtx":
+		// The size is checked before the entries, and the graph, are
+		// allocated.
+		x, err := mtx.ReadChecked(strings.NewReader(req.Data), func(rows, cols, nnz int) error {
+			if rows > s.config.MaxEdges || cols > s.config.MaxEdges {
+				return fmt.Errorf("matrix of [%d, %d] exceeds the limit of %d rows and columns", rows, cols, s.config.MaxEdges)
+			}
+			if nnz > s.config.MaxEdges {
+				return fmt.Errorf("%d entries exceed the limit of %d", nnz, s.config.MaxEdges)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Symmetric matrices have up to twice the entries of their header.
+		if len(x.Entries) > s.config.MaxEdges {
+			return nil, fmt.Errorf("%d entries exceed the limit of %d", len(x.Entries), s.config.MaxEdges)
+		}
+		p.G, p.U, p.V = x.Graph()
+		p.colOffset = x.Rows
+		if req.Objective == "weight" {
+			p.opts.Objective = x.Objective()
+		}
+	case "edges":
+		if len(req.Edges) > s.config.MaxEdges {
+			return nil, fmt.Errorf("%d edges exceed the limit of %d", len(req.Edges), s.config.MaxEdges)
+		}
+		uu, vv := make([]int, len(req.Edges)), make([]int, len(req.Edges))
+		for i, e := range req.Edges {
+			if e[0] < 0 || e[1] < 0 || e[0] >= s.config.MaxEdges || e[1] >= s.config.MaxEdges {
+				return nil, fmt.Errorf("edge %d has a vertex out of range [0, %d)", i, s.config.MaxEdges)
+			}
+			uu[i], vv[i] = e[0], e[1]
+		}
+		if len(uu) == 0 {
+			return nil, fmt.Errorf("empty edge list")
+		}
+		if req.Objective == "weight" {
+			return nil, fmt.Errorf("objective weight needs a matrix")
+		}
+		p.G, p.U, p.V, p.colOffset = bimax.EdgeListGraph(uu, vv)
+	default:
+		return nil, fmt.Errorf("unknown format %q", req.Format)
+	}
+
+	if weights != nil {
+		if n == 0 || m == 0 {
+			return nil, fmt.Errorf("empty matrix")
+		}
+		pattern := make([]uint8, len(weights))
+		for i, w := range weights {
+			if math.IsNaN(w) {
+				w, weights[i] = 0, 0
+			}
+			if w < 0 || (w != 0 && w != 1 && req.Objective != "weight") {
+				return nil, fmt.Errorf("%v is not a zero or 1, use objective weight for weighted matrices", w)
+			}
+			if w != 0 {
+				pattern[i] = 1
+			}
+		}
+		p.G, p.U, p.V = bimax.BinaryMatrixGraph(n, m, pattern)
+		p.colOffset = n
+		if req.Objective == "weight" {
+			p.opts.Objective = bimax.EdgeWeight(func(u, v int) float64 { return weights[u*m+v-n] })
+		}
+	}
+
+	switch req.Objective {
+	case "", "area", "weight":
+	case "vertices":
+		p.opts.Objective = bimax.VertexWeight(func(int) float64 { return 1 })
+	default:
+		return nil, fmt.Errorf("unknown objective %q", req.Objective)
+	}
+	switch req.Mode {
+	case "", "largest":
+		p.opts.Limit = 1
+	case "top-k":
+		if req.K <= 0 {
+			return nil, fmt.Errorf("mode top-k needs k greater than 0")
+		}
+		p.opts.Limit = req.K
+	case "enumerate":
+	default:
+		return nil, fmt.Errorf("unknown mode %q", req.Mode)
+	}
+	p.opts.MinRows, p.opts.MinCols = req.MinRows, req.MinCols
+
+	p.timeout = s.config.Timeout
+	if t := time.Duration(req.TimeoutMS) * time.Millisecond; t > 0 && t < p.timeout {
+		p.timeout = t
+	}
+	return p, nil
+}
+
+func (s *Server) checkCells(n, m int) error {
+	if n > 0 && m > s.config.MaxCells/n {
+		return fmt.Errorf("matrix of [%d, %d] exceeds the limit of %d cells", n, m, s.config.MaxCells)
+	}
+	return nil
+}
+
+// response converts the results of p so that columns are column indices.
+func (p *problem) response(results []*bimax.BiMaxResult) *Response {
+	for _, r := range results {
+		cols := bimax.NewSet()
+		r.Cols.Each(func(v int) (_ bool) {
+			cols.Add(v - p.colOffset)
+			return
+		})
+		r.Cols = cols.SetOp
+	}
+	if results == nil {
+		results = []*bimax.BiMaxResult{}
+	}
+	return &Response{Results: results, RowLabels: p.rowLabels, ColLabels: p.colLabels}
+}
@@ -0,0 +1,330 @@
+// Package server serves bimax over HTTP with a JSON API.
+//
+//	POST   /v1/bimax      solve a problem and wait for the results
+//	POST   /v1/jobs       start solving a problem in the background
+//	GET    /v1/jobs/{id}  poll a job for its status and results
+//	DELETE /v1/jobs/{id}  cancel a job
+//
+// A problem is posted as a JSON 'Request', or as the raw text of a csv, tsv,
+// Matrix Market or edge list file with the options of the request given as
+// URL query parameters.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxsei/bimax"
+)
+
+// DefaultTimeout is the longest time spent on a problem unless configured
+// otherwise.
+const DefaultTimeout = 30 * time.Second
+
+// Config limits the problems that a server accepts.  Zero fields take their
+// defaults.
+type Config struct {
+	// MaxBodyBytes is the largest request body, 32 MiB by default.
+	MaxBodyBytes int64
+	// MaxCells is the largest number of cells of a dense matrix, 10⁷ by
+	// default.
+	MaxCells int
+	// MaxEdges is the largest number of edges, or entries of a sparse matrix,
+	// and bounds the vertices of an edge list and the rows and columns of a
+	// sparse matrix.  It is 10⁷ by default.
+	MaxEdges int
+	// Timeout is the longest time spent on a problem, 30 seconds by default.
+	// Requests may ask for less.
+	Timeout time.Duration
+	// MaxJobs is the largest number of jobs running at once, 4 by default.
+	MaxJobs int
+	// JobTTL is how long a finished job is kept for polling, an hour by
+	// default.
+	JobTTL time.Duration
+}
+
+// Server is an http.Handler serving the bimax API.
+type Server struct {
+	config Config
+	mux    *http.ServeMux
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	running int
+}
+
+// New returns a server with the limits of config.
+func New(config Config) *Server {
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = 32 << 20
+	}
+	if config.MaxCells <= 0 {
+		config.MaxCells = 1e7
+	}
+	if config.MaxEdges <= 0 {
+		config.MaxEdges = 1e7
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.MaxJobs <= 0 {
+		config.MaxJobs = 4
+	}
+	if config.JobTTL <= 0 {
+		config.JobTTL = time.Hour
+	}
+	s := &Server{config: config, mux: http.NewServeMux(), jobs: make(map[string]*job)}
+	s.mux.HandleFunc("/v1/bimax", s.handleSolve)
+	s.mux.HandleFunc("/v1/jobs", s.handleJobs)
+	s.mux.HandleFunc("/v1/jobs/", s.handleJob)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) { s.mux.ServeHTTP(w, r) }
+
+// errorResponse is the body of every response with an error status.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{err.Error()})
+}
+
+// readProblem reads the problem in the body of r.
+func (s *Server) readProblem(w http.ResponseWriter, r *http.Request) (*problem, int, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", s.config.MaxBodyBytes)
+		}
+		return nil, http.StatusBadRequest, fmt.Errorf("reading request body: %v", err)
+	}
+	req, err := requestFromQuery(r.URL.Query())
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	case "text/csv":
+		req.Format, req.Data = "csv", string(body)
+	case "text/tab-separated-values":
+		req.Format, req.Data = "tsv", string(body)
+	case "text/x-matrix-market", "application/x-matrix-market":
+		req.Format, req.Data = "mtx", string(body)
+	default:
+		// Plain text is read in the format given by the query.
+		req.Data = string(body)
+		if req.Format == "edges" {
+			if req.Edges, err = parseEdges(req.Data); err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+		}
+	}
+	p, err := s.newProblem(req)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return p, 0, nil
+}
+
+// parseEdges parses an edge list with one pair of vertices per line.
+func parseEdges(data string) (edges [][2]int, err error) {
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var e [2]int
+		if _, err := fmt.Sscan(strings.Replace(line, ",", " ", 1), &e[0], &e[1]); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		edges = append(edges, e)
+	}
+	return
+}
+
+// solve finds the bicliques of p within its timeout.
+func (p *problem) solve(ctx context.Context) ([]*bimax.BiMaxResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	return bimax.BiMaxAllContext(ctx, p.G, p.U, p.V, p.opts)
+}
+
+func (s *Server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	p, status, err := s.readProblem(w, r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	if !s.acquire() {
+		writeError(w, http.StatusTooManyRequests, fmt.Errorf("%d problems are already running", s.config.MaxJobs))
+		return
+	}
+	defer s.release()
+	results, err := p.solve(r.Context())
+	if err == context.DeadlineExceeded {
+		writeError(w, http.StatusGatewayTimeout, fmt.Errorf("timed out after %v", p.timeout))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p.response(results))
+}
+
+// acquire reserves a slot for a running problem, returning false when all are
+// taken.
+func (s *Server) acquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running >= s.config.MaxJobs {
+		return false
+	}
+	s.running++
+	return true
+}
+
+func (s *Server) release() {
+	s.mu.Lock()
+	s.running--
+	s.mu.Unlock()
+}
+
+// Job statuses.
+const (
+	StatusRunning  = "running"
+	StatusDone     = "done"
+	StatusFailed   = "failed"
+	StatusCanceled = "canceled"
+)
+
+// JobResponse is the state of a job.  Response holds the results of a job
+// that is done, or the bicliques found before a job failed or was canceled.
+type JobResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	*Response
+}
+
+type job struct {
+	cancel   context.CancelFunc
+	finished time.Time
+	state    JobResponse
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	p, status, err := s.readProblem(w, r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	if !s.acquire() {
+		writeError(w, http.StatusTooManyRequests, fmt.Errorf("%d problems are already running", s.config.MaxJobs))
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{cancel: cancel, state: JobResponse{ID: newID(), Status: StatusRunning}}
+	s.mu.Lock()
+	s.expire()
+	s.jobs[j.state.ID] = j
+	state := j.state
+	s.mu.Unlock()
+
+	go func() {
+		defer s.release()
+		defer cancel()
+		results, err := p.solve(ctx)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		j.finished = time.Now()
+		j.state.Response = p.response(results)
+		switch {
+		case err == context.Canceled:
+			j.state.Status = StatusCanceled
+		case err == context.DeadlineExceeded:
+			j.state.Status, j.state.Error = StatusFailed, fmt.Sprintf("timed out after %v", p.timeout)
+		case err != nil:
+			j.state.Status, j.state.Error = StatusFailed, err.Error()
+		default:
+			j.state.Status = StatusDone
+		}
+	}()
+
+	w.Header().Set("Location", "/v1/jobs/"+state.ID)
+	writeJSON(w, http.StatusAccepted, state)
+}
+
+// expire removes finished jobs older than the TTL.  The caller holds s.mu.
+func (s *Server) expire() {
+	for id, j := range s.jobs {
+		if !j.finished.IsZero() && time.Since(j.finished) > s.config.JobTTL {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	s.mu.Lock()
+	s.expire()
+	j, ok := s.jobs[id]
+	var state JobResponse
+	if ok {
+		state = j.state
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no job %q", id))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, state)
+	case http.MethodDelete:
+		j.cancel()
+		writeJSON(w, http.StatusAccepted, state)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func post(t *testing.T, h http.Handler, target, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	r.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestSolve(t *testing.T) {
+	s := New(Config{})
+	matrix := `{"matrix": [[1, 1, 0], [1, 1, 1], [0, 1, 1]], "mode": "top-k", "k": 2}`
+	csv := ",a,b,c\nx,1,1,0\ny,1,1,1\nz,0,1,1\n"
+	for _, tt := range []struct {
+		name, target, contentType, body string
+	}{
+		{"json", "/v1/bimax", "application/json", matrix},
+		{"csv", "/v1/bimax?mode=top-k&k=2", "text/csv", csv},
+		{"edges", "/v1/bimax?format=edges&mode=top-k&k=2", "text/plain", "0 3\n0 4\n1 3\n1 4\n1 5\n2 4\n2 5\n"},
+	} {
+		w := post(t, s, tt.target, tt.contentType, tt.body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", tt.name, w.Code, w.Body)
+		}
+		var resp struct {
+			Results []struct{ Rows, Cols []int }
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Results) != 2 {
+			t.Fatalf("%s: got %d results, want 2", tt.name, len(resp.Results))
+		}
+		if got := resp.Results[0]; len(got.Rows)*len(got.Cols) != 4 {
+			t.Errorf("%s: largest biclique %v × %v, want area 4", tt.name, got.Rows, got.Cols)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	s := New(Config{MaxBodyBytes: 64, MaxCells: 4})
+	if w := post(t, s, "/v1/bimax", "application/json", `{"matrix": [[1, 1, 1], [1, 1, 1]]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("too many cells: status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	body := `{"matrix": [[1]], "format": "matrix"` + strings.Repeat(" ", 64) + "}"
+	if w := post(t, s, "/v1/bimax", "application/json", body); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: status %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// A body that fails to be read for any other reason is a bad request.
+	r := httptest.NewRequest(http.MethodPost, "/v1/bimax", iotest.ErrReader(errors.New("connection reset")))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("broken body: status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	s := New(Config{MaxEdges: 100})
+	// A row may have a greater number than the columns it joins.
+	w := post(t, s, "/v1/bimax", "application/json", `{"format": "edges", "edges": [[5, 1], [5, 2]]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("edges: status %d: %s", w.Code, w.Body)
+	}
+	if got := w.Body.String(); !strings.Contains(got, `"rows":[5],"cols":[1,2]`) {
+		t.Errorf("edges: got %s", got)
+	}
+
+	const header = "%%MatrixMarket matrix coordinate pattern general\n"
+	for _, tt := range []struct{ name, body string }{
+		{"negative vertex", `{"format": "edges", "edges": [[-1, 1]]}`},
+		{"large vertex", `{"format": "edges", "edges": [[0, 100]]}`},
+		{"negative size", `{"format": "mtx", "data": "` + header + `-2 2 0\n"}`},
+		{"large size", `{"format": "mtx", "data": "` + header + `1000 1 0\n"}`},
+		{"many entries", `{"format": "mtx", "data": "` + header + `100000 100000 9000000000\n"}`},
+	} {
+		if w := post(t, s, "/v1/bimax", "application/json", tt.body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, w.Code, http.StatusBadRequest, w.Body)
+		}
+	}
+}
+
+func TestJobs(t *testing.T) {
+	s := New(Config{})
+	w := post(t, s, "/v1/jobs", "application/json", `{"matrix": [[1, 1], [1, 0]], "mode": "enumerate"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	location := w.Header().Get("Location")
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		w = httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, location, nil))
+		var job JobResponse
+		if err := json.NewDecoder(bytes.NewReader(w.Body.Bytes())).Decode(&job); err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == StatusDone {
+			if len(job.Results) != 2 {
+				t.Errorf("got %d results, want 2", len(job.Results))
+			}
+			break
+		}
+		if job.Status != StatusRunning || time.Now().After(deadline) {
+			t.Fatalf("job %s: %s", job.Status, job.Error)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/jobs/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing job: status %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,174 @@
+package set
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// A concurrent set spreads its values over shards by their hash so that
+// goroutines working on different values rarely wait on the same lock.  Each
+// shard is a map guarded by its own RWMutex.
+
+// shardCount is the number of shards of a concurrent set.
+const shardCount = 32
+
+type shard[T comparable] struct {
+	sync.RWMutex
+	// set maps each value to the value first stored, which 'LoadOrStore'
+	// returns.
+	set map[T]T
+}
+
+type concurrentSet[T comparable] struct {
+	seed   maphash.Seed
+	shards [shardCount]shard[T]
+}
+
+func (s *concurrentSet[T]) shard(k T) *shard[T] {
+	return &s.shards[maphash.Comparable(s.seed, k)%shardCount]
+}
+
+/////////////////////////////////////////
+//  Start Set Interface Implmentation  //
+/////////////////////////////////////////
+// Set creation
+func (s *concurrentSet[T]) New() Set[T] { return NewConcurrent[T]() }
+func (s *concurrentSet[T]) Clone() Set[T] {
+	product := NewConcurrent[T]()
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		for k, v := range sh.set {
+			product.set.shard(k).set[k] = v
+		}
+		sh.RUnlock()
+	}
+	return product
+}
+
+// Key related operations
+func (s *concurrentSet[T]) Has(k T) bool {
+	sh := s.shard(k)
+	sh.RLock()
+	_, has := sh.set[k]
+	sh.RUnlock()
+	return has
+}
+func (s *concurrentSet[T]) Add(k T) (ok bool) {
+	_, loaded := s.loadOrStore(k)
+	return !loaded
+}
+func (s *concurrentSet[T]) Delete(k T) (ok bool) {
+	sh := s.shard(k)
+	sh.Lock()
+	defer sh.Unlock()
+	if _, has := sh.set[k]; !has {
+		return false
+	}
+	delete(sh.set, k)
+	return true
+}
+
+// Set cardinality
+func (s *concurrentSet[T]) Len() (n int) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		n += len(sh.set)
+		sh.RUnlock()
+	}
+	return
+}
+
+// Iteration
+func (s *concurrentSet[T]) Range(do func(k T) (done bool)) {
+	var keys []T
+	for i := range s.shards {
+		// The keys of a shard are copied so that do may change the set.
+		sh := &s.shards[i]
+		sh.RLock()
+		keys = keys[:0]
+		for k := range sh.set {
+			keys = append(keys, k)
+		}
+		sh.RUnlock()
+		for _, k := range keys {
+			if do(k) {
+				return
+			}
+		}
+	}
+}
+
+/////////////////////////////////////////
+//   End Set Interface Implmentation   //
+/////////////////////////////////////////
+
+func (s *concurrentSet[T]) loadOrStore(k T) (actual T, loaded bool) {
+	sh := s.shard(k)
+	// Most calls in a busy set find the value already present, which only
+	// needs the read lock.
+	sh.RLock()
+	actual, loaded = sh.set[k]
+	sh.RUnlock()
+	if loaded {
+		return
+	}
+	sh.Lock()
+	defer sh.Unlock()
+	if actual, loaded = sh.set[k]; loaded {
+		return
+	}
+	sh.set[k] = k
+	return k, false
+}
+
+// NewConcurrent returns an empty set that is safe to use from many goroutines
+// at once.  Each of Has, Add, Delete, 'AddIfAbsent' and 'LoadOrStore' is
+// atomic, while Len, Range and the operations built on them, such as
+// Intersection, may observe the changes made while they run.
+func NewConcurrent[T comparable]() *ConcurrentSet[T] {
+	set := &concurrentSet[T]{seed: maphash.MakeSeed()}
+	for i := range set.shards {
+		set.shards[i].set = make(map[T]T)
+	}
+	return &ConcurrentSet[T]{&SetOp[T]{set}, set}
+}
+
+// NewConcurrentWith returns a concurrent set with the passed values.
+func NewConcurrentWith[T comparable](vv ...T) *ConcurrentSet[T] {
+	result := NewConcurrent[T]()
+	result.Update(vv...)
+	return result
+}
+
+// ConcurrentSet represent a unique collection of values shared between
+// goroutines
+type ConcurrentSet[T comparable] struct {
+	*SetOp[T]
+	set *concurrentSet[T]
+}
+
+// AddIfAbsent adds v unless it is present and reports whether it was added.
+// Of many goroutines adding the same value exactly one is told it added it.
+func (s *ConcurrentSet[T]) AddIfAbsent(v T) (added bool) { return s.set.Add(v) }
+
+// LoadOrStore returns the value of the set equal to v if there is one, and
+// otherwise adds v and returns it.  loaded reports whether v was present.
+func (s *ConcurrentSet[T]) LoadOrStore(v T) (actual T, loaded bool) { return s.set.loadOrStore(v) }
+
+// Operations that require type assertion this set's type
+func (s *ConcurrentSet[T]) Intersection(other Set[T]) (product *ConcurrentSet[T]) {
+	return s.intersection(other).(*ConcurrentSet[T])
+}
+func (s *ConcurrentSet[T]) Difference(other Set[T]) (product *ConcurrentSet[T]) {
+	return s.difference(other).(*ConcurrentSet[T])
+}
+func (s *ConcurrentSet[T]) SymmetricDifference(other Set[T]) (product *ConcurrentSet[T]) {
+	return s.symmetricDifference(other).(*ConcurrentSet[T])
+}
+
+func (s *ConcurrentSet[T]) Copy() (product *ConcurrentSet[T]) { return s.Clone().(*ConcurrentSet[T]) }
+func (s *ConcurrentSet[T]) Union(other Set[T]) (product *ConcurrentSet[T]) {
+	return s.union(other).(*ConcurrentSet[T])
+}
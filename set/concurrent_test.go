@@ -0,0 +1,142 @@
+package set_test
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/maxsei/bimax/set"
+)
+
+func TestConcurrent(t *testing.T) {
+	const workers, n = 8, 3000
+	s := set.NewConcurrent[int]()
+	// Each worker adds the values of its own stripe and deletes every third of
+	// them, so the values left do not depend on how the workers interleave.
+	var added, deleted [workers]int
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := w; v < n; v += workers {
+				if s.AddIfAbsent(v) {
+					added[w]++
+				}
+				if s.AddIfAbsent(v) {
+					t.Errorf("%d was added twice", v)
+				}
+			}
+			for v := w; v < n; v += workers {
+				if v%3 == 0 && s.Delete(v) {
+					deleted[w]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for w := 0; w < workers; w++ {
+		stripe := (n - w + workers - 1) / workers
+		thirds := 0
+		for v := w; v < n; v += workers {
+			if v%3 == 0 {
+				thirds++
+			}
+		}
+		if added[w] != stripe || deleted[w] != thirds {
+			t.Errorf("worker %d added %d and deleted %d values, want %d and %d", w, added[w], deleted[w], stripe, thirds)
+		}
+	}
+	var want []int
+	for v := 0; v < n; v++ {
+		if v%3 != 0 {
+			want = append(want, v)
+		}
+	}
+	if got := s.Sorted(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %d values, want the %d values that are not multiples of 3", len(got), len(want))
+	}
+	if got := s.Card(); got != len(want) {
+		t.Errorf("cardinality: got %d, want %d", got, len(want))
+	}
+	if got := s.Intersection(set.NewWith(0, 1, 2, 3, -1)).Sorted(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("intersection: got %v, want [1 2]", got)
+	}
+	if got := s.Copy().Difference(set.NewWith(want[1:]...)).Sorted(); !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("difference: got %v, want %v", got, want[:1])
+	}
+}
+
+// TestConcurrentRace has goroutines contend for the same values and is meant
+// to be run with -race.  Only what holds for any interleaving is checked.
+func TestConcurrentRace(t *testing.T) {
+	const workers, n = 8, 1000
+	s := set.NewConcurrent[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := 0; v < n; v++ {
+				s.AddIfAbsent(v)
+				s.Has(v + 1)
+				if v%3 == 0 {
+					s.Delete(v - 1)
+				}
+			}
+			s.Each(func(v int) (_ bool) {
+				if v < 0 || n <= v {
+					t.Errorf("%d was never added", v)
+				}
+				s.Add(v)
+				return
+			})
+			if c := s.Card(); c > n {
+				t.Errorf("cardinality %d is above %d", c, n)
+			}
+			s.Intersection(set.NewWith(0, 1, 2))
+			s.Copy()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentAddIfAbsent(t *testing.T) {
+	const workers = 16
+	s := set.NewConcurrent[string]()
+	var mu sync.Mutex
+	winners := 0
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.AddIfAbsent("BRCA1") {
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if winners != 1 {
+		t.Errorf("%d goroutines added the same value, want 1", winners)
+	}
+}
+
+func TestConcurrentLoadOrStore(t *testing.T) {
+	s := set.NewConcurrentWith(math.Copysign(0, -1))
+	// 0 and -0 are equal, so the -0 already stored is returned.
+	actual, loaded := s.LoadOrStore(0)
+	if !loaded || !math.Signbit(actual) {
+		t.Errorf("got %v, %v, want -0, true", actual, loaded)
+	}
+	if actual, loaded := s.LoadOrStore(1); loaded || actual != 1 {
+		t.Errorf("got %v, %v, want 1, false", actual, loaded)
+	}
+	if got := s.Card(); got != 2 {
+		t.Errorf("got %d values, want 2", got)
+	}
+}
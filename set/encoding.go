@@ -0,0 +1,111 @@
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// compareFunc returns cmp.Compare for T when T is a number or a string, and
+// for other types of those kinds, such as named strings, a comparison through
+// reflect.  It returns nil for values of any other kind.
+func compareFunc[T comparable]() func(v1, v2 T) int {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return ordered[T, int]()
+	case int8:
+		return ordered[T, int8]()
+	case int16:
+		return ordered[T, int16]()
+	case int32:
+		return ordered[T, int32]()
+	case int64:
+		return ordered[T, int64]()
+	case uint:
+		return ordered[T, uint]()
+	case uint8:
+		return ordered[T, uint8]()
+	case uint16:
+		return ordered[T, uint16]()
+	case uint32:
+		return ordered[T, uint32]()
+	case uint64:
+		return ordered[T, uint64]()
+	case uintptr:
+		return ordered[T, uintptr]()
+	case float32:
+		return ordered[T, float32]()
+	case float64:
+		return ordered[T, float64]()
+	case string:
+		return ordered[T, string]()
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(v1, v2 T) int { return cmp.Compare(reflect.ValueOf(v1).Int(), reflect.ValueOf(v2).Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(v1, v2 T) int { return cmp.Compare(reflect.ValueOf(v1).Uint(), reflect.ValueOf(v2).Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(v1, v2 T) int { return cmp.Compare(reflect.ValueOf(v1).Float(), reflect.ValueOf(v2).Float()) }
+	case reflect.String:
+		return func(v1, v2 T) int { return cmp.Compare(reflect.ValueOf(v1).String(), reflect.ValueOf(v2).String()) }
+	}
+	return nil
+}
+
+// ordered returns cmp.Compare for U as a comparison of T, which must be U.
+func ordered[T comparable, U cmp.Ordered]() func(v1, v2 T) int {
+	return any(cmp.Compare[U]).(func(v1, v2 T) int)
+}
+
+// Sorted returns the values of a set in increasing order when they are numbers
+// or strings and in the order of the set otherwise.
+func (s *SetOp[T]) Sorted() []T {
+	vv := s.Values()
+	if compare := compareFunc[T](); compare != nil {
+		slices.SortFunc(vv, compare)
+	}
+	return vv
+}
+
+// MarshalJSON encodes the set as an array of its values in the order of
+// 'Sorted'.
+func (s *SetOp[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.Sorted()) }
+
+// UnmarshalJSON replaces the values of the set with an array of values.  A
+// SetOp without a set is given an unordered set.
+func (s *SetOp[T]) UnmarshalJSON(b []byte) error {
+	var vv []T
+	if err := json.Unmarshal(b, &vv); err != nil {
+		return err
+	}
+	if s.Set == nil {
+		s.Set = New[T]()
+	}
+	s.Remove(s.Values()...)
+	s.Update(vv...)
+	return nil
+}
+
+// UnmarshalJSON replaces the values of the set with an array of values.
+func (s *UnorderedSet[T]) UnmarshalJSON(b []byte) error {
+	if s.SetOp == nil {
+		*s = *New[T]()
+	}
+	return s.SetOp.UnmarshalJSON(b)
+}
+
+// UnmarshalJSON replaces the values of the set with an array of values.  An
+// OrderedSet without a comparison orders its values as 'Sorted' does.
+func (o *OrderedSet[T]) UnmarshalJSON(b []byte) error {
+	if o.SetOp == nil {
+		compare := compareFunc[T]()
+		if compare == nil {
+			compare = func(v1, v2 T) int { return 0 }
+		}
+		*o = *NewOrdered(func(v1, v2 T) bool { return compare(v1, v2) <= 0 })
+	}
+	return o.SetOp.UnmarshalJSON(b)
+}
@@ -0,0 +1,433 @@
+package set
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// A roaring bitmap splits its values by their high 16 bits into containers
+// that hold the low 16 bits either as a sorted array, while there are few, or
+// as a bitmap of 2¹⁶ bits.  Sets of millions of sparse values stay compact and
+// intersections, differences and unions work a container at a time.
+//
+// Chambi et al., Better bitmap performance with Roaring bitmaps (2016).
+
+// arrayMax is the largest number of values held by an array container.
+const arrayMax = 4096
+
+type container struct {
+	// array holds the sorted values unless bitmap is set.
+	array  []uint16
+	bitmap []uint64
+	card   int
+}
+
+func (c *container) has(lo uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[lo>>6]&(1<<(lo&63)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	return i < len(c.array) && c.array[i] == lo
+}
+
+func (c *container) add(lo uint16) (ok bool) {
+	if c.bitmap != nil {
+		w, bit := lo>>6, uint64(1)<<(lo&63)
+		if c.bitmap[w]&bit != 0 {
+			return false
+		}
+		c.bitmap[w] |= bit
+		c.card++
+		return true
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	if i < len(c.array) && c.array[i] == lo {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = lo
+	c.card++
+	if c.card > arrayMax {
+		c.toBitmap()
+	}
+	return true
+}
+
+func (c *container) del(lo uint16) (ok bool) {
+	if c.bitmap != nil {
+		w, bit := lo>>6, uint64(1)<<(lo&63)
+		if c.bitmap[w]&bit == 0 {
+			return false
+		}
+		c.bitmap[w] &^= bit
+		c.card--
+		if c.card <= arrayMax {
+			c.toArray()
+		}
+		return true
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= lo })
+	if i == len(c.array) || c.array[i] != lo {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.card--
+	return true
+}
+
+// each calls do with the values of c in increasing order until do returns
+// done.
+func (c *container) each(do func(lo uint16) (done bool)) (done bool) {
+	if c.bitmap == nil {
+		for _, lo := range c.array {
+			if do(lo) {
+				return true
+			}
+		}
+		return
+	}
+	for w, word := range c.bitmap {
+		for word != 0 {
+			t := word & -word
+			if do(uint16(w<<6 + bits.TrailingZeros64(word))) {
+				return true
+			}
+			word ^= t
+		}
+	}
+	return
+}
+
+func (c *container) toBitmap() {
+	c.bitmap = make([]uint64, 1<<10)
+	for _, lo := range c.array {
+		c.bitmap[lo>>6] |= 1 << (lo & 63)
+	}
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	array := make([]uint16, 0, c.card)
+	c.each(func(lo uint16) (_ bool) {
+		array = append(array, lo)
+		return
+	})
+	c.array, c.bitmap = array, nil
+}
+
+func (c *container) clone() *container {
+	return &container{
+		array:  append([]uint16(nil), c.array...),
+		bitmap: append([]uint64(nil), c.bitmap...),
+		card:   c.card,
+	}
+}
+
+// fromBitmap returns a container of a bitmap holding card values.
+func fromBitmap(bitmap []uint64, card int) *container {
+	c := &container{bitmap: bitmap, card: card}
+	if card <= arrayMax {
+		c.toArray()
+	}
+	return c
+}
+
+func popcount(bitmap []uint64) (card int) {
+	for _, w := range bitmap {
+		card += bits.OnesCount64(w)
+	}
+	return
+}
+
+func and(a, b *container) *container {
+	switch {
+	case a.bitmap != nil && b.bitmap != nil:
+		bitmap := make([]uint64, len(a.bitmap))
+		for i := range bitmap {
+			bitmap[i] = a.bitmap[i] & b.bitmap[i]
+		}
+		return fromBitmap(bitmap, popcount(bitmap))
+	case a.bitmap != nil:
+		a, b = b, a
+		fallthrough
+	case b.bitmap != nil:
+		array := make([]uint16, 0, len(a.array))
+		for _, lo := range a.array {
+			if b.has(lo) {
+				array = append(array, lo)
+			}
+		}
+		return &container{array: array, card: len(array)}
+	}
+	array := make([]uint16, 0, min(len(a.array), len(b.array)))
+	for i, j := 0, 0; i < len(a.array) && j < len(b.array); {
+		switch {
+		case a.array[i] < b.array[j]:
+			i++
+		case a.array[i] > b.array[j]:
+			j++
+		default:
+			array = append(array, a.array[i])
+			i++
+			j++
+		}
+	}
+	return &container{array: array, card: len(array)}
+}
+
+func andNot(a, b *container) *container {
+	switch {
+	case a.bitmap != nil:
+		bitmap := append([]uint64(nil), a.bitmap...)
+		if b.bitmap != nil {
+			for i := range bitmap {
+				bitmap[i] &^= b.bitmap[i]
+			}
+		} else {
+			for _, lo := range b.array {
+				bitmap[lo>>6] &^= 1 << (lo & 63)
+			}
+		}
+		return fromBitmap(bitmap, popcount(bitmap))
+	case b.bitmap != nil:
+		array := make([]uint16, 0, len(a.array))
+		for _, lo := range a.array {
+			if !b.has(lo) {
+				array = append(array, lo)
+			}
+		}
+		return &container{array: array, card: len(array)}
+	}
+	array := make([]uint16, 0, len(a.array))
+	j := 0
+	for _, lo := range a.array {
+		for j < len(b.array) && b.array[j] < lo {
+			j++
+		}
+		if j == len(b.array) || b.array[j] != lo {
+			array = append(array, lo)
+		}
+	}
+	return &container{array: array, card: len(array)}
+}
+
+func or(a, b *container) *container {
+	if a.bitmap == nil && b.bitmap == nil && len(a.array)+len(b.array) <= arrayMax {
+		array := make([]uint16, 0, len(a.array)+len(b.array))
+		i, j := 0, 0
+		for i < len(a.array) && j < len(b.array) {
+			switch {
+			case a.array[i] < b.array[j]:
+				array = append(array, a.array[i])
+				i++
+			case a.array[i] > b.array[j]:
+				array = append(array, b.array[j])
+				j++
+			default:
+				array = append(array, a.array[i])
+				i++
+				j++
+			}
+		}
+		array = append(append(array, a.array[i:]...), b.array[j:]...)
+		return &container{array: array, card: len(array)}
+	}
+	bitmap := make([]uint64, 1<<10)
+	for _, c := range []*container{a, b} {
+		if c.bitmap != nil {
+			for i, w := range c.bitmap {
+				bitmap[i] |= w
+			}
+			continue
+		}
+		for _, lo := range c.array {
+			bitmap[lo>>6] |= 1 << (lo & 63)
+		}
+	}
+	return fromBitmap(bitmap, popcount(bitmap))
+}
+
+// roaringSet is a roaring bitmap of the values [0, 2³²).
+type roaringSet struct {
+	// keys are the sorted high 16 bits of the values in each container.
+	keys       []uint16
+	containers []*container
+	card       int
+}
+
+func split(v int) (hi, lo uint16) {
+	if v < 0 || uint64(v) > 1<<32-1 {
+		panic(fmt.Sprintf("%d is out of the range [0, 2³²) of a roaring set", v))
+	}
+	return uint16(v >> 16), uint16(v)
+}
+
+// find returns the index of the container of hi, or where it belongs.
+func (r *roaringSet) find(hi uint16) (int, bool) {
+	i := sort.Search(len(r.keys), func(i int) bool { return r.keys[i] >= hi })
+	return i, i < len(r.keys) && r.keys[i] == hi
+}
+
+// push appends a non-empty container.
+func (r *roaringSet) push(hi uint16, c *container) {
+	if c.card == 0 {
+		return
+	}
+	r.keys = append(r.keys, hi)
+	r.containers = append(r.containers, c)
+	r.card += c.card
+}
+
+/////////////////////////////////////////
+//  Start Set Interface Implmentation  //
+/////////////////////////////////////////
+// Set creation
+func (r *roaringSet) New() Set[int] { return NewRoaring() }
+func (r *roaringSet) Clone() Set[int] {
+	product := NewRoaring()
+	for i, c := range r.containers {
+		product.set.push(r.keys[i], c.clone())
+	}
+	return product
+}
+
+// Key related operations
+func (r *roaringSet) Has(k int) bool {
+	if k < 0 || uint64(k) > 1<<32-1 {
+		return false
+	}
+	hi, lo := split(k)
+	i, ok := r.find(hi)
+	return ok && r.containers[i].has(lo)
+}
+func (r *roaringSet) Add(k int) (ok bool) {
+	hi, lo := split(k)
+	i, found := r.find(hi)
+	if !found {
+		r.keys = append(r.keys, 0)
+		copy(r.keys[i+1:], r.keys[i:])
+		r.keys[i] = hi
+		r.containers = append(r.containers, nil)
+		copy(r.containers[i+1:], r.containers[i:])
+		r.containers[i] = &container{}
+	}
+	if ok = r.containers[i].add(lo); ok {
+		r.card++
+	}
+	return
+}
+func (r *roaringSet) Delete(k int) (ok bool) {
+	if !r.Has(k) {
+		return false
+	}
+	hi, lo := split(k)
+	i, _ := r.find(hi)
+	r.containers[i].del(lo)
+	r.card--
+	if r.containers[i].card == 0 {
+		r.keys = append(r.keys[:i], r.keys[i+1:]...)
+		r.containers = append(r.containers[:i], r.containers[i+1:]...)
+	}
+	return true
+}
+
+// Set cardinality
+func (r *roaringSet) Len() int { return r.card }
+
+// Iteration
+func (r *roaringSet) Range(do func(k int) (done bool)) {
+	for i, c := range r.containers {
+		hi := int(r.keys[i]) << 16
+		if c.each(func(lo uint16) bool { return do(hi | int(lo)) }) {
+			return
+		}
+	}
+}
+
+/////////////////////////////////////////
+//   End Set Interface Implmentation   //
+/////////////////////////////////////////
+
+// combine merges the containers of a and b with op.  Containers only in a are
+// kept when keepA is set and containers only in b when keepB is set.
+func combine(a, b *roaringSet, op func(a, b *container) *container, keepA, keepB bool) *RoaringSet {
+	product := NewRoaring()
+	i, j := 0, 0
+	for i < len(a.keys) || j < len(b.keys) {
+		switch {
+		case j == len(b.keys) || (i < len(a.keys) && a.keys[i] < b.keys[j]):
+			if keepA {
+				product.set.push(a.keys[i], a.containers[i].clone())
+			}
+			i++
+		case i == len(a.keys) || a.keys[i] > b.keys[j]:
+			if keepB {
+				product.set.push(b.keys[j], b.containers[j].clone())
+			}
+			j++
+		default:
+			product.set.push(a.keys[i], op(a.containers[i], b.containers[j]))
+			i++
+			j++
+		}
+	}
+	return product
+}
+
+// Intersect, Subtract and Unite work a container at a time when other is also
+// a roaring set.
+func (r *roaringSet) Intersect(other Set[int]) (product Set[int], ok bool) {
+	if b, ok := other.(*roaringSet); ok {
+		return combine(r, b, and, false, false), true
+	}
+	return nil, false
+}
+func (r *roaringSet) Subtract(other Set[int]) (product Set[int], ok bool) {
+	if b, ok := other.(*roaringSet); ok {
+		return combine(r, b, andNot, true, false), true
+	}
+	return nil, false
+}
+func (r *roaringSet) Unite(other Set[int]) (product Set[int], ok bool) {
+	if b, ok := other.(*roaringSet); ok {
+		return combine(r, b, or, true, true), true
+	}
+	return nil, false
+}
+
+// NewRoaring returns an empty set backed by a roaring bitmap.  It holds ints
+// in the range [0, 2³²).  Intersections, differences and unions with other
+// roaring sets work on whole containers of values at once.
+func NewRoaring() *RoaringSet {
+	set := &roaringSet{}
+	return &RoaringSet{&SetOp[int]{set}, set}
+}
+
+// NewRoaringWith returns a roaring set with the passed values.
+func NewRoaringWith(vv ...int) *RoaringSet {
+	result := NewRoaring()
+	result.Update(vv...)
+	return result
+}
+
+type RoaringSet struct {
+	*SetOp[int]
+	set *roaringSet
+}
+
+// Operations that require type assertion this set's type
+func (r *RoaringSet) Intersection(other Set[int]) (product *RoaringSet) {
+	return r.intersection(other).(*RoaringSet)
+}
+func (r *RoaringSet) Difference(other Set[int]) (product *RoaringSet) {
+	return r.difference(other).(*RoaringSet)
+}
+func (r *RoaringSet) SymmetricDifference(other Set[int]) (product *RoaringSet) {
+	return r.symmetricDifference(other).(*RoaringSet)
+}
+func (r *RoaringSet) Copy() (product *RoaringSet) { return r.Clone().(*RoaringSet) }
+func (r *RoaringSet) Union(other Set[int]) (product *RoaringSet) {
+	return r.union(other).(*RoaringSet)
+}
@@ -0,0 +1,60 @@
+package set_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/maxsei/bimax/set"
+)
+
+// randomValues returns n values spread over few containers so that both
+// array and bitmap containers are exercised.
+func randomValues(rng *rand.Rand, n int) []int {
+	vv := make([]int, n)
+	for i := range vv {
+		vv[i] = rng.Intn(4)<<16 | rng.Intn(1<<16)
+		if i%2 == 0 {
+			// Dense values fill bitmap containers.
+			vv[i] = rng.Intn(6000)
+		}
+	}
+	return vv
+}
+
+func TestRoaring(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		x, y := randomValues(rng, rng.Intn(20000)), randomValues(rng, rng.Intn(20000))
+		rx, ry := set.NewRoaringWith(x...), set.NewRoaringWith(y...)
+		ux, uy := set.NewWith(x...), set.NewWith(y...)
+		if rx.Card() != ux.Card() {
+			t.Fatalf("cardinality: got %d, want %d", rx.Card(), ux.Card())
+		}
+		for _, tt := range []struct {
+			name      string
+			got, want *set.SetOp[int]
+		}{
+			{"intersection", rx.Intersection(ry).SetOp, ux.Intersection(uy).SetOp},
+			{"difference", rx.Difference(ry).SetOp, ux.Difference(uy).SetOp},
+			{"union", rx.Union(ry).SetOp, ux.Union(uy).SetOp},
+			{"intersection with a map set", rx.Intersection(uy).SetOp, ux.Intersection(uy).SetOp},
+		} {
+			// Values are ranged over in increasing order.
+			if got, want := tt.got.Values(), tt.want.Sorted(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s: got %d values, want %d", tt.name, len(got), len(want))
+			}
+		}
+
+		// Deleting values turns bitmap containers back into arrays.
+		for _, v := range x {
+			rx.Delete(v)
+			if rx.Has(v) {
+				t.Fatalf("%d is present after delete", v)
+			}
+		}
+		if rx.Card() != 0 {
+			t.Fatalf("got %d values after deleting all", rx.Card())
+		}
+	}
+}
@@ -0,0 +1,550 @@
+// Package set implements sets of any comparable type.  An UnorderedSet is
+// backed by a map and an OrderedSet additionally keeps its values sorted by a
+// user defined comparison.  Both embed a SetOp giving them the operations
+// shared by every Set.  Neither is safe to share between goroutines that
+// change it; a ConcurrentSet is.
+package set
+
+import (
+	"fmt"
+	"sort"
+)
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                  Iterators                                  //
+/////////////////////////////////////////////////////////////////////////////////
+
+func newSetCh[T comparable]() *setCh[T] {
+	return &setCh[T]{ch: make(chan T)}
+}
+
+type setCh[T comparable] struct {
+	ch chan T
+}
+
+func (sc setCh[T]) send(k T) { sc.ch <- k }
+func (sc setCh[T]) Iter() T  { return <-sc.ch }
+func (sc setCh[T]) Close()   { close(sc.ch) }
+
+// Set is implemented by every kind of set.  Types outside of this package,
+// such as persistent sets or compressed bitmaps, can implement Set to be used
+// with SetOp and with the operations of the sets of this package.
+type Set[T comparable] interface {
+	// New returns an empty set of the same kind.
+	New() Set[T]
+	// Clone returns a copy of the set of the same kind.
+	Clone() Set[T]
+	// Has reports whether v is in the set.
+	Has(v T) bool
+	// Add adds v to the set and reports whether it was not already present.
+	Add(v T) (ok bool)
+	// Delete removes v from the set and reports whether it was present.
+	Delete(v T) (ok bool)
+	// Len returns the number of values in the set.
+	Len() int
+	// Range calls do with each value of the set until do returns done.
+	Range(do func(v T) (done bool))
+}
+
+// Intersector is implemented by a Set that can intersect with some other sets
+// faster than value by value, such as a compressed bitmap with another of its
+// kind.  Intersect returns the values in both sets as a set of the kind
+// returned by New, or false if it has no fast path for other, in which case
+// the values are compared one at a time.  Wrappers such as SetOp and the sets
+// of this package are removed from both sets before the call, so other is the
+// Set behind them.
+type Intersector[T comparable] interface {
+	Intersect(other Set[T]) (product Set[T], ok bool)
+}
+
+// Subtractor is implemented by a Set that can find the values not in some
+// other sets faster than value by value, as Intersector.
+type Subtractor[T comparable] interface {
+	Subtract(other Set[T]) (product Set[T], ok bool)
+}
+
+// Uniter is implemented by a Set that can find the values in either of it and
+// some other sets faster than value by value, as Intersector.
+type Uniter[T comparable] interface {
+	Unite(other Set[T]) (product Set[T], ok bool)
+}
+
+// base returns the Set behind any SetOp wrapping s, such as the sets of this
+// package.
+func base[T comparable](s Set[T]) Set[T] {
+	for {
+		w, ok := s.(interface{ base() Set[T] })
+		if !ok {
+			return s
+		}
+		s = w.base()
+	}
+}
+
+// SetOp gives all Interfaces that implement Set access to the following methods:
+type SetOp[T comparable] struct {
+	Set[T]
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                  Mutations                                  //
+/////////////////////////////////////////////////////////////////////////////////
+
+// mutate is not for external use.  It is intended to make the code for 'Update'
+// and 'Remove' smaller
+func (s *SetOp[T]) mutate(mutateFunc func(T) bool, vv []T) (change int) {
+	init := s.Card()
+	for _, v := range vv {
+		mutateFunc(v)
+	}
+	if init > s.Card() {
+		return init - s.Card()
+	}
+	return s.Card() - init
+}
+
+func (s *SetOp[T]) Update(vv ...T) (added int)   { return s.mutate(s.Add, vv) }
+func (s *SetOp[T]) Remove(vv ...T) (deleted int) { return s.mutate(s.Delete, vv) }
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                 Operations                                  //
+/////////////////////////////////////////////////////////////////////////////////
+
+// predicateSet compares one set to another. If all is set to true then the
+// function will try and find the union of the two sets else it will find the
+// difference
+func (s *SetOp[T]) predicateSet(other Set[T], all bool) (product Set[T]) {
+	if fast, ok := base(s.Set).(Intersector[T]); ok && all {
+		if product, ok := fast.Intersect(base(other)); ok {
+			return product
+		}
+	}
+	if fast, ok := base(s.Set).(Subtractor[T]); ok && !all {
+		if product, ok := fast.Subtract(base(other)); ok {
+			return product
+		}
+	}
+	product = s.New()
+	// Iterate over smaller set if unionPredicate
+	otherOp := &SetOp[T]{other}
+	a, b := s, otherOp
+	if all && (b.Card() < a.Card()) {
+		b = s
+		a = otherOp
+	}
+	a.Range(func(k T) (_ bool) {
+		// Add keys to product, skipping if predicate matches.
+		if b.Has(k) != all {
+			return
+		}
+		product.Add(k)
+		return
+	})
+	return
+}
+func (s *SetOp[T]) intersection(other Set[T]) (product Set[T]) { return s.predicateSet(other, true) }
+func (s *SetOp[T]) difference(other Set[T]) (product Set[T])   { return s.predicateSet(other, false) }
+func (s *SetOp[T]) symmetricDifference(other Set[T]) (product Set[T]) {
+	union := s.predicateSet(other, true)
+	// Diff 1
+	diff1 := &SetOp[T]{(&SetOp[T]{other}).predicateSet(union, false)}
+	// Diff 2
+	diff2 := s.predicateSet(union, false)
+
+	diff2.Range(func(k T) (_ bool) {
+		diff1.Add(k)
+		return
+	})
+	product = diff1.Set
+	return
+}
+
+func (s *SetOp[T]) union(other Set[T]) (product Set[T]) {
+	if fast, ok := base(s.Set).(Uniter[T]); ok {
+		if product, ok := fast.Unite(base(other)); ok {
+			return product
+		}
+	}
+	// The product is a copy of s so that it is of the same kind as s.
+	c := &SetOp[T]{s.Clone()}
+	other.Range(func(k T) (_ bool) {
+		c.Add(k)
+		return
+	})
+	return c.Set
+}
+func (s *SetOp[T]) Each(do func(v T) (done bool)) { s.Range(do) }
+func (s *SetOp[T]) base() Set[T]                  { return s.Set }
+
+// Card returns the cardinality of the set.
+func (s *SetOp[T]) Card() int { return s.Len() }
+
+// Chan sends the values of the set on a channel that is closed after the last
+// value.
+func (s *SetOp[T]) Chan() (iterator *setCh[T]) {
+	iterator = newSetCh[T]()
+	go func() {
+		s.Range(func(k T) (_ bool) {
+			iterator.send(k)
+			return
+		})
+		iterator.Close()
+	}()
+	return
+}
+
+func (s *SetOp[T]) Get(i int) (v T) {
+	if s.Card() <= i {
+		panic(fmt.Sprintf("%d out of range of set with cardinality %d", i, s.Card()))
+	}
+	j := 0
+	s.Range(func(k T) (done bool) {
+		if j == i {
+			v = k
+			return true
+		}
+		j++
+		return
+	})
+	return
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                 Properties                                  //
+/////////////////////////////////////////////////////////////////////////////////
+
+type JointSetCategory int
+
+const (
+	JointSets JointSetCategory = iota
+	JointSetDisJoint
+	JointSetNone
+	// Joint Set Category
+	JointSetSubset
+	JointSetSuperset
+	JointSetEqualset
+)
+
+func (s *SetOp[T]) JointSetCategory(other Set[T]) JointSetCategory {
+	// TODO: could make this function variadice for multiple set comparison <15-01-21, Max Schulte> //
+	// TODO: deal with empty set which is both a disjoint and a subset of other <16-01-21, Max Schulte> //
+
+	// Separate set into what is smaller and larger set
+	smol, larg := Set[T](s), other
+	if s.Card() > other.Len() {
+		smol, larg = other, s
+	}
+
+	// See if the set should include or exclude
+	var predicate bool
+	smol.Range(func(k T) (done bool) {
+		predicate = other.Has(k)
+		done = true
+		return
+	})
+
+	// Iterate over the smallest set and check for items in other set
+	predicateFailed := false
+	smol.Range(func(k T) (done bool) {
+		if larg.Has(k) != predicate {
+			predicateFailed = true
+			return true
+		}
+		return
+	})
+
+	// Return what we were trying to prove all along
+	// If the predicate failed to be proven return no joint set.
+	if predicateFailed {
+		return JointSetNone
+	}
+	// If predicate is false then neither two sets had similiar elements.
+	if predicate == false {
+		return JointSetDisJoint
+	}
+	// Otherwise the sets are joint based on some relation of cardinality
+	switch {
+	case s.Card() == other.Len():
+		return JointSetEqualset
+	case s.Card() < other.Len():
+		return JointSetSubset
+	case s.Card() > other.Len():
+		return JointSetSuperset
+	}
+	panic("unreachable")
+}
+
+func (s *SetOp[T]) IsDisjoint(other Set[T]) bool {
+	return s.JointSetCategory(other) == JointSetDisJoint
+}
+func (s *SetOp[T]) IsSubset(other Set[T]) bool {
+	return s.JointSetCategory(other) == JointSetSubset
+}
+func (s *SetOp[T]) IsSuperset(other Set[T]) bool {
+	return s.JointSetCategory(other) == JointSetSuperset
+}
+func (s *SetOp[T]) IsEqual(other Set[T]) bool {
+	return s.JointSetCategory(other) == JointSetEqualset
+}
+
+func (s *SetOp[T]) Values() []T {
+	vv := make([]T, 0, s.Card())
+	s.Range(func(k T) (_ bool) {
+		vv = append(vv, k)
+		return
+	})
+	return vv
+}
+
+// String returns set{<values>}
+func (s *SetOp[T]) String() string {
+	vv := s.Values()
+	vvStr := []byte(fmt.Sprint(vv))
+	vvStr[0] = '{'
+	vvStr[len(vvStr)-1] = '}'
+	return "set" + string(vvStr)
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                  Builders                                   //
+/////////////////////////////////////////////////////////////////////////////////
+
+// New returns an emtpy set
+func New[T comparable]() *UnorderedSet[T] { return NewWith[T]() }
+
+// NewFromSlice returns a set of the values in vv
+func NewFromSlice[T comparable](vv []T) *UnorderedSet[T] { return NewWith(vv...) }
+
+// NewWith returns a set with the passed values
+func NewWith[T comparable](vv ...T) *UnorderedSet[T] {
+	set := &unorderedSet[T]{
+		set: make(map[T]struct{}),
+	}
+	result := &UnorderedSet[T]{&SetOp[T]{set}, set}
+	result.Update(vv...)
+	return result
+}
+
+// UnorderedSet represent a unique collection of values
+type UnorderedSet[T comparable] struct {
+	*SetOp[T]
+	set *unorderedSet[T]
+}
+
+type unorderedSet[T comparable] struct {
+	set map[T]struct{}
+}
+
+/////////////////////////////////////////
+//  Start Set Interface Implmentation  //
+/////////////////////////////////////////
+// Set creation
+func (s *unorderedSet[T]) New() Set[T] { return New[T]() }
+func (s *unorderedSet[T]) Clone() Set[T] {
+	product := New[T]()
+	for k := range s.set {
+		product.set.set[k] = struct{}{}
+	}
+	return product
+}
+
+// Key related operations
+func (s *unorderedSet[T]) Has(k T) bool { _, has := s.set[k]; return has }
+func (s *unorderedSet[T]) Add(k T) (ok bool) {
+	if _, has := s.set[k]; has {
+		return false
+	}
+	s.set[k] = struct{}{}
+	return true
+}
+func (s *unorderedSet[T]) Delete(k T) (ok bool) {
+	if _, has := s.set[k]; !has {
+		return false
+	}
+	delete(s.set, k)
+	return true
+}
+
+// Set cardinality
+func (s *unorderedSet[T]) Len() int { return len(s.set) }
+
+// Iteration
+func (s *unorderedSet[T]) Range(do func(k T) (done bool)) {
+	for k := range s.set {
+		done := do(k)
+		if done {
+			return
+		}
+	}
+}
+
+/////////////////////////////////////////
+//   End Set Interface Implmentation   //
+/////////////////////////////////////////
+
+// Operations that require type assertion this set's type
+func (s *UnorderedSet[T]) Intersection(other Set[T]) (product *UnorderedSet[T]) {
+	return s.intersection(other).(*UnorderedSet[T])
+}
+func (s *UnorderedSet[T]) Difference(other Set[T]) (product *UnorderedSet[T]) {
+	return s.difference(other).(*UnorderedSet[T])
+}
+func (s *UnorderedSet[T]) SymmetricDifference(other Set[T]) (product *UnorderedSet[T]) {
+	return s.symmetricDifference(other).(*UnorderedSet[T])
+}
+
+func (s *UnorderedSet[T]) Copy() (product *UnorderedSet[T]) { return s.Clone().(*UnorderedSet[T]) }
+func (s *UnorderedSet[T]) Union(other Set[T]) (product *UnorderedSet[T]) {
+	return s.union(other).(*UnorderedSet[T])
+}
+
+// Order returns copy of the current set as an ordered set
+func (s *UnorderedSet[T]) Order(cmp func(v1, v2 T) bool) *OrderedSet[T] {
+	result := NewOrderedWithCapacity(cmp, s.Card())
+	for k := range s.set.set {
+		result.set.Add(k)
+	}
+	return result
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+//                                 Ordered Set                                 //
+/////////////////////////////////////////////////////////////////////////////////
+
+func NewOrdered[T comparable](cmp func(v1, v2 T) bool) *OrderedSet[T] {
+	return NewOrderedWithCapacity(cmp, 0)
+}
+
+func NewOrderedFromSlice[T comparable](cmp func(v1, v2 T) bool, vv []T) *OrderedSet[T] {
+	return NewOrderedWith(cmp, vv...)
+}
+func NewOrderedWith[T comparable](cmp func(v1, v2 T) bool, vv ...T) *OrderedSet[T] {
+	result := NewOrderedWithCapacity(cmp, 0)
+	result.Update(vv...)
+	return result
+}
+
+func NewOrderedWithCapacity[T comparable](cmp func(v1, v2 T) bool, capacity int) *OrderedSet[T] {
+	set := &orderedSet[T]{
+		set:     make(map[T]struct{}),
+		keys:    make([]T, 0, capacity),
+		compare: cmp,
+	}
+	return &OrderedSet[T]{&SetOp[T]{set}, set}
+}
+
+type OrderedSet[T comparable] struct {
+	*SetOp[T]
+	set *orderedSet[T]
+}
+
+type orderedSet[T comparable] struct {
+	set     map[T]struct{}
+	keys    []T
+	compare func(v1, v2 T) bool
+}
+
+func (o *orderedSet[T]) search(k T) int {
+	// Find the user defined sort comparison index
+	return sort.Search(len(o.keys), func(i int) bool {
+		return o.compare(k, o.keys[i])
+	})
+}
+
+/////////////////////////////////////////
+//  Start Set Interface Implmentation  //
+/////////////////////////////////////////
+// Set creation
+func (o *orderedSet[T]) New() Set[T] { return NewOrdered(o.compare) }
+func (o *orderedSet[T]) Clone() Set[T] {
+	product := NewOrderedWithCapacity(o.compare, o.Len())
+	product.set.keys = append(product.set.keys, o.keys...)
+	for k := range o.set {
+		product.set.set[k] = struct{}{}
+	}
+	return product
+}
+
+// Key related operations
+func (o *orderedSet[T]) Has(k T) bool { _, ok := o.set[k]; return ok }
+func (o *orderedSet[T]) Add(k T) (ok bool) {
+	if _, has := o.set[k]; has {
+		return false
+	}
+	i := o.search(k)
+	// Shift over, copy mem, and insert element at i
+	o.keys = append(o.keys, k)
+	copy(o.keys[i+1:], o.keys[i:len(o.keys)-1])
+	o.keys[i] = k
+	// Add to map
+	o.set[k] = struct{}{}
+	return true
+}
+func (o *orderedSet[T]) Delete(k T) (ok bool) {
+	if _, has := o.set[k]; !has {
+		return false
+	}
+	// Keys that compare equal can be in any order so k is found by value
+	// rather than by searching.
+	i := 0
+	for o.keys[i] != k {
+		i++
+	}
+	// Remove k from the sorted set
+	o.keys = append(o.keys[:i], o.keys[i+1:]...)
+	// Remove from map
+	delete(o.set, k)
+	return true
+}
+
+// Set cardinality
+func (o *orderedSet[T]) Len() int { return len(o.keys) }
+
+// Iteration
+func (o *orderedSet[T]) Range(do func(k T) (done bool)) {
+	for _, k := range o.keys {
+		done := do(k)
+		if done {
+			return
+		}
+	}
+}
+
+/////////////////////////////////////////
+//   End Set Interface Implmentation   //
+/////////////////////////////////////////
+
+// Operations that require type assertion this set's type
+func (o *OrderedSet[T]) Intersection(other Set[T]) (product *OrderedSet[T]) {
+	return o.intersection(other).(*OrderedSet[T])
+}
+func (o *OrderedSet[T]) Difference(other Set[T]) (product *OrderedSet[T]) {
+	return o.difference(other).(*OrderedSet[T])
+}
+func (o *OrderedSet[T]) SymmetricDifference(other Set[T]) (product *OrderedSet[T]) {
+	return o.symmetricDifference(other).(*OrderedSet[T])
+}
+func (o *OrderedSet[T]) Copy() (product *OrderedSet[T]) { return o.Clone().(*OrderedSet[T]) }
+func (o *OrderedSet[T]) Union(other Set[T]) (product *OrderedSet[T]) {
+	return o.union(other).(*OrderedSet[T])
+}
+
+// UnOrder returns copy of the current ordered set as a set
+func (o *OrderedSet[T]) Unorder() *UnorderedSet[T] {
+	result := New[T]()
+	for _, k := range o.set.keys {
+		result.Add(k)
+	}
+	return result
+}
+
+// Intersection returns the values in both a and b as a set of the same kind as
+// a.
+func Intersection[T comparable](a, b Set[T]) Set[T] { return (&SetOp[T]{a}).intersection(b) }
+
+// Difference returns the values in a but not in b as a set of the same kind as
+// a.
+func Difference[T comparable](a, b Set[T]) Set[T] { return (&SetOp[T]{a}).difference(b) }
+
+// Union returns the values in a or b as a set of the same kind as a.
+func Union[T comparable](a, b Set[T]) Set[T] { return (&SetOp[T]{a}).union(b) }
@@ -0,0 +1,194 @@
+package set_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/maxsei/bimax/set"
+)
+
+func TestStrings(t *testing.T) {
+	a := set.NewWith("BRCA1", "TP53", "EGFRvIII")
+	b := set.NewWith("TP53", "MYC")
+	if got := a.Intersection(b).Values(); len(got) != 1 || got[0] != "TP53" {
+		t.Errorf("intersection: got %v, want [TP53]", got)
+	}
+	if got := a.Union(b).Card(); got != 4 {
+		t.Errorf("union: got %d values, want 4", got)
+	}
+	if got := a.Difference(b).Sorted(); len(got) != 2 || got[0] != "BRCA1" || got[1] != "EGFRvIII" {
+		t.Errorf("difference: got %v, want [BRCA1 EGFRvIII]", got)
+	}
+
+	o := a.Order(func(v1, v2 string) bool { return len(v1) <= len(v2) })
+	if got := o.Get(0); got != "TP53" {
+		t.Errorf("shortest: got %q, want TP53", got)
+	}
+	o.Delete("TP53")
+	if got := o.Get(0); got != "BRCA1" {
+		t.Errorf("shortest after delete: got %q, want BRCA1", got)
+	}
+
+	b2, err := json.Marshal(a.SetOp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b2) != `["BRCA1","EGFRvIII","TP53"]` {
+		t.Errorf("json: got %s", b2)
+	}
+	var c set.UnorderedSet[string]
+	if err := json.Unmarshal(b2, &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsEqual(a) {
+		t.Errorf("json round trip: got %v, want %v", c.String(), a.String())
+	}
+}
+
+func TestStructs(t *testing.T) {
+	type cell struct{ row, col int }
+	s := set.NewWith(cell{0, 1}, cell{1, 0})
+	if s.Add(cell{0, 1}) {
+		t.Error("added a value that was already present")
+	}
+	if !s.Has(cell{1, 0}) || s.Has(cell{1, 1}) {
+		t.Errorf("membership of %v is wrong", s.String())
+	}
+	if got := s.Remove(cell{0, 1}, cell{1, 1}); got != 1 {
+		t.Errorf("removed %d values, want 1", got)
+	}
+}
+
+// bits is a Set of small non-negative ints implemented outside the package.
+type bits uint64
+
+func (b *bits) New() set.Set[int]   { return new(bits) }
+func (b *bits) Clone() set.Set[int] { c := *b; return &c }
+func (b *bits) Has(v int) bool      { return *b&(1<<v) != 0 }
+func (b *bits) Add(v int) (ok bool) {
+	ok = !b.Has(v)
+	*b |= 1 << v
+	return
+}
+func (b *bits) Delete(v int) (ok bool) {
+	ok = b.Has(v)
+	*b &^= 1 << v
+	return
+}
+func (b *bits) Len() (n int) {
+	for x := *b; x != 0; x &= x - 1 {
+		n++
+	}
+	return
+}
+func (b *bits) Range(do func(v int) (done bool)) {
+	for v := 0; v < 64; v++ {
+		if b.Has(v) && do(v) {
+			return
+		}
+	}
+}
+
+func TestExternalSet(t *testing.T) {
+	b := &set.SetOp[int]{Set: new(bits)}
+	if got := b.Update(1, 3, 5, 3); got != 3 {
+		t.Errorf("added %d values, want 3", got)
+	}
+	u := set.NewWith(3, 4, 5)
+	if got := u.Intersection(b).Sorted(); len(got) != 2 || got[0] != 3 || got[1] != 5 {
+		t.Errorf("intersection: got %v, want [3 5]", got)
+	}
+	if got := u.Union(b).Card(); got != 4 {
+		t.Errorf("union: got %d values, want 4", got)
+	}
+	if !b.IsSubset(set.NewWith(1, 2, 3, 5)) {
+		t.Errorf("%v is not a subset of {1, 2, 3, 5}", b)
+	}
+	if got := b.Get(1); got != 3 {
+		t.Errorf("second value: got %d, want 3", got)
+	}
+}
+
+// wordBits is a bits that intersects, subtracts and unites with other wordBits
+// a word at a time, counting how often it does.
+type wordBits struct {
+	bits
+	fast *int
+}
+
+func (b *wordBits) New() set.Set[int]   { return &wordBits{fast: b.fast} }
+func (b *wordBits) Clone() set.Set[int] { c := *b; return &c }
+func (b *wordBits) word(other set.Set[int], op func(x, y bits) bits) (set.Set[int], bool) {
+	o, ok := other.(*wordBits)
+	if !ok {
+		return nil, false
+	}
+	*b.fast++
+	return &wordBits{op(b.bits, o.bits), b.fast}, true
+}
+func (b *wordBits) Intersect(other set.Set[int]) (set.Set[int], bool) {
+	return b.word(other, func(x, y bits) bits { return x & y })
+}
+func (b *wordBits) Subtract(other set.Set[int]) (set.Set[int], bool) {
+	return b.word(other, func(x, y bits) bits { return x &^ y })
+}
+func (b *wordBits) Unite(other set.Set[int]) (set.Set[int], bool) {
+	return b.word(other, func(x, y bits) bits { return x | y })
+}
+
+func TestFastPath(t *testing.T) {
+	var fast int
+	a := &set.SetOp[int]{Set: &wordBits{fast: &fast}}
+	b := &set.SetOp[int]{Set: &wordBits{fast: &fast}}
+	a.Update(1, 2, 3)
+	b.Update(2, 3, 4)
+	for _, tt := range []struct {
+		name string
+		got  set.Set[int]
+		want []int
+	}{
+		{"intersection", set.Intersection[int](a, b), []int{2, 3}},
+		{"difference", set.Difference[int](a, b), []int{1}},
+		{"union", set.Union[int](a, b), []int{1, 2, 3, 4}},
+		// Sets other than wordBits are compared value by value.
+		{"intersection with a map set", set.Intersection[int](a, set.NewWith(3, 4)), []int{3}},
+		{"union with a map set", set.Union[int](a, set.NewWith(5)), []int{1, 2, 3, 5}},
+	} {
+		got := (&set.SetOp[int]{Set: tt.got}).Sorted()
+		if _, ok := tt.got.(*wordBits); !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %T %v, want %v", tt.name, tt.got, got, tt.want)
+		}
+	}
+	if fast != 3 {
+		t.Errorf("took the fast path %d times, want 3", fast)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	if got := set.NewWith(3, -1, 20, 0).Sorted(); !reflect.DeepEqual(got, []int{-1, 0, 3, 20}) {
+		t.Errorf("ints: got %v", got)
+	}
+	if got := set.NewWith[uint8](200, 7).Sorted(); !reflect.DeepEqual(got, []uint8{7, 200}) {
+		t.Errorf("bytes: got %v", got)
+	}
+	if got := set.NewWith(2.5, -0.5).Sorted(); !reflect.DeepEqual(got, []float64{-0.5, 2.5}) {
+		t.Errorf("floats: got %v", got)
+	}
+	// Named types of ordered kinds are ordered by their values.
+	type gene string
+	if got := set.NewWith[gene]("TP53", "BRCA1", "MYC").Sorted(); !reflect.DeepEqual(got, []gene{"BRCA1", "MYC", "TP53"}) {
+		t.Errorf("named strings: got %v", got)
+	}
+	type count int
+	if got := set.NewWith[count](4, 1).Sorted(); !reflect.DeepEqual(got, []count{1, 4}) {
+		t.Errorf("named ints: got %v", got)
+	}
+	// Other values are left in the order of the set.
+	type cell struct{ row, col int }
+	o := set.NewOrdered(func(v1, v2 cell) bool { return true })
+	o.Update(cell{1, 0}, cell{0, 1})
+	if got := o.Sorted(); !reflect.DeepEqual(got, o.Values()) {
+		t.Errorf("structs: got %v, want %v", got, o.Values())
+	}
+}
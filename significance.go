@@ -0,0 +1,193 @@
+package bimax
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/yourbasic/graph"
+)
+
+// NullModel is a random bipartite graph model that the size of a biclique is
+// compared against.
+type NullModel int
+
+const (
+	// NullDegree keeps the expected degree of every row and column.  An edge
+	// (u, v) occurs with probability deg(u)deg(v)/|E(G)|.
+	NullDegree NullModel = iota
+	// NullBernoulli places every edge independently with the density of G.
+	NullBernoulli
+)
+
+// SignificanceOptions configures 'SignificanceWithOptions'.
+type SignificanceOptions struct {
+	// Null is the null model used for the analytic p-value.
+	Null NullModel
+	// Samples is the number of random graphs used to estimate the p-value
+	// empirically.  When Samples is greater than zero each random graph is
+	// made by degree-preserving edge swaps of G and Null is ignored.
+	Samples int
+	// Swaps is the number of edge swaps made for each random graph.  It
+	// defaults to ten times the number of edges.
+	Swaps int
+	// Seed seeds the random edge swaps.  The same seed gives the same p-value.
+	Seed int64
+}
+
+// Significance computes the p-value of the size of the biclique in result
+// under the degree-preserving null model of the bipartite graph G of (U ∪ V,
+// E(G)).  The p-value is also stored in the PValue of result.
+func Significance(result *BiMaxResult, G *graph.Mutable, U, V *UnorderedSet) float64 {
+	return SignificanceWithOptions(result, G, U, V, SignificanceOptions{})
+}
+
+// SignificanceWithOptions computes the p-value of the size of the biclique in
+// result as configured by opts.  The p-value is also stored in the PValue of
+// result.
+//
+// The analytic p-value bounds the probability that any biclique of at least
+// the same number of rows and columns occurs by the expected number of such
+// bicliques in the null model.  The empirical p-value is the fraction of
+// random graphs whose largest biclique has at least the same area.
+func SignificanceWithOptions(result *BiMaxResult, G *graph.Mutable, U, V *UnorderedSet, opts SignificanceOptions) float64 {
+	a, b := result.Rows.Card(), result.Cols.Card()
+	var p float64
+	switch {
+	case a == 0 || b == 0:
+		p = 1
+	case opts.Samples > 0:
+		p = empiricalSignificance(a*b, G, U, V, opts)
+	default:
+		p = math.Min(1, math.Exp(logExpectedBicliques(a, b, G, U, V, opts.Null)))
+	}
+	result.PValue = p
+	return p
+}
+
+// logExpectedBicliques returns the log of the expected number of a by b
+// bicliques in the null model of G.
+func logExpectedBicliques(a, b int, G *graph.Mutable, U, V *UnorderedSet, null NullModel) float64 {
+	n, m := U.Card(), V.Card()
+	edges := 0
+	U.Each(func(u int) (_ bool) {
+		edges += G.Degree(u)
+		return
+	})
+	if edges == 0 {
+		return math.Inf(-1)
+	}
+	if null == NullBernoulli {
+		density := float64(edges) / float64(n*m)
+		return logChoose(n, a) + logChoose(m, b) + float64(a*b)*math.Log(density)
+	}
+	// Summing the product of deg(u)deg(v)/|E| over every choice of a rows and
+	// b columns separates into elementary symmetric polynomials of the row
+	// degrees raised to b and the column degrees raised to a.
+	logDegrees := func(set *UnorderedSet, power int) []float64 {
+		result := make([]float64, 0, set.Card())
+		set.Each(func(v int) (_ bool) {
+			if d := G.Degree(v); d > 0 {
+				result = append(result, float64(power)*math.Log(float64(d)))
+			}
+			return
+		})
+		return result
+	}
+	rows := logElementarySymmetric(logDegrees(U, b), a)
+	cols := logElementarySymmetric(logDegrees(V, a), b)
+	return rows + cols - float64(a*b)*math.Log(float64(edges))
+}
+
+// logElementarySymmetric returns the log of the elementary symmetric
+// polynomial of degree k in the values whose logs are given by xx.
+func logElementarySymmetric(xx []float64, k int) float64 {
+	if k > len(xx) {
+		return math.Inf(-1)
+	}
+	e := make([]float64, k+1)
+	for j := 1; j <= k; j++ {
+		e[j] = math.Inf(-1)
+	}
+	for _, x := range xx {
+		for j := k; j > 0; j-- {
+			e[j] = logAddExp(e[j], e[j-1]+x)
+		}
+	}
+	return e[k]
+}
+
+func logAddExp(x, y float64) float64 {
+	if x < y {
+		x, y = y, x
+	}
+	if math.IsInf(y, -1) {
+		return x
+	}
+	return x + math.Log1p(math.Exp(y-x))
+}
+
+func logChoose(n, k int) float64 {
+	if k < 0 || n < k {
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// empiricalSignificance estimates the p-value of a biclique with the given area
+// from random graphs made by degree-preserving edge swaps of G.
+func empiricalSignificance(area int, G *graph.Mutable, U, V *UnorderedSet, opts SignificanceOptions) float64 {
+	type edge struct{ u, v int }
+	var edges []edge
+	U.Each(func(u int) (_ bool) {
+		G.Visit(u, func(v int, _ int64) (_ bool) {
+			if V.Has(v) {
+				edges = append(edges, edge{u, v})
+			}
+			return
+		})
+		return
+	})
+	// The edges are sorted so that the swaps only depend on the seed.
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].u != edges[j].u {
+			return edges[i].u < edges[j].u
+		}
+		return edges[i].v < edges[j].v
+	})
+	swaps := opts.Swaps
+	if swaps <= 0 {
+		swaps = 10 * len(edges)
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	exceeded := 0
+	for sample := 0; sample < opts.Samples; sample++ {
+		H := graph.New(G.Order())
+		for _, e := range edges {
+			H.AddBoth(e.u, e.v)
+		}
+		shuffled := append([]edge(nil), edges...)
+		for i := 0; i < swaps && len(shuffled) > 1; i++ {
+			x, y := rng.Intn(len(shuffled)), rng.Intn(len(shuffled))
+			e1, e2 := shuffled[x], shuffled[y]
+			// Swap the columns of both edges unless it would create an edge
+			// that already exists.
+			if e1.u == e2.u || e1.v == e2.v || H.Edge(e1.u, e2.v) || H.Edge(e2.u, e1.v) {
+				continue
+			}
+			H.DeleteBoth(e1.u, e1.v)
+			H.DeleteBoth(e2.u, e2.v)
+			H.AddBoth(e1.u, e2.v)
+			H.AddBoth(e2.u, e1.v)
+			shuffled[x], shuffled[y] = edge{e1.u, e2.v}, edge{e2.u, e1.v}
+		}
+		if null := BiMax(H, U, V); int(null.Weight) >= area {
+			exceeded++
+		}
+	}
+	return float64(exceeded+1) / float64(opts.Samples+1)
+}
@@ -0,0 +1,72 @@
+package bimax_test
+
+import (
+	"testing"
+
+	"github.com/maxsei/bimax"
+	"github.com/maxsei/bimax/generate"
+)
+
+// plantedMatrix returns a random n by n matrix of the given density and the
+// same matrix with a k by k block of ones in its top left corner.
+func plantedMatrix(n, k int, density float64, seed int64) (random, planted []uint8) {
+	random, err := generate.ErdosRenyi(n, n, density, seed)
+	if err != nil {
+		panic(err)
+	}
+	planted = append([]uint8(nil), random...)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			planted[i*n+j] = 1
+		}
+	}
+	return
+}
+
+func TestSignificance(t *testing.T) {
+	const n = 40
+	random, planted := plantedMatrix(n, 8, 0.15, 1)
+	for _, tc := range []struct {
+		name string
+		opts bimax.SignificanceOptions
+		// The p-value of the planted biclique is below low and that of the
+		// largest biclique of the random matrix is above high.
+		low, high float64
+	}{
+		{"degree", bimax.SignificanceOptions{Null: bimax.NullDegree}, 1e-6, 0.5},
+		{"bernoulli", bimax.SignificanceOptions{Null: bimax.NullBernoulli}, 1e-6, 0.5},
+		{"swaps", bimax.SignificanceOptions{Samples: 19, Seed: 1}, 0.05 + 1e-9, 0.1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			G, U, V := bimax.BinaryMatrixGraph(n, n, planted)
+			r := bimax.BiMax(G, U, V)
+			if r.Rows.Card() < 8 || r.Cols.Card() < 8 {
+				t.Fatalf("planted biclique not found: %v", r)
+			}
+			if p := bimax.SignificanceWithOptions(r, G, U, V, tc.opts); p >= tc.low || r.PValue != p {
+				t.Errorf("planted: got p-value %v (stored %v), want below %v", p, r.PValue, tc.low)
+			}
+
+			G, U, V = bimax.BinaryMatrixGraph(n, n, random)
+			r = bimax.BiMax(G, U, V)
+			if p := bimax.SignificanceWithOptions(r, G, U, V, tc.opts); p <= tc.high {
+				t.Errorf("random: got p-value %v for %d by %d, want above %v", p, r.Rows.Card(), r.Cols.Card(), tc.high)
+			}
+		})
+	}
+}
+
+func TestSignificanceSeed(t *testing.T) {
+	const n = 20
+	random, _ := plantedMatrix(n, 0, 0.3, 2)
+	G, U, V := bimax.BinaryMatrixGraph(n, n, random)
+	r := bimax.BiMax(G, U, V)
+	opts := bimax.SignificanceOptions{Samples: 9, Seed: 7}
+	if p, q := bimax.SignificanceWithOptions(r, G, U, V, opts), bimax.SignificanceWithOptions(r, G, U, V, opts); p != q {
+		t.Errorf("the same seed gave p-values %v and %v", p, q)
+	}
+	empty := &bimax.BiMaxResult{Rows: bimax.NewSet().SetOp, Cols: bimax.NewSet().SetOp}
+	if p := bimax.Significance(empty, G, U, V); p != 1 {
+		t.Errorf("empty biclique: got p-value %v, want 1", p)
+	}
+}
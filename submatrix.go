@@ -0,0 +1,107 @@
+package bimax
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BiMaxResults is a list of bicliques of the same n by m matrix, whose
+// columns are numbered [n, n+m) as in the results of 'BiMaxBinaryMatrix'.
+type BiMaxResults []*BiMaxResult
+
+// Permutation returns permutations of the rows and columns of an n by m matrix
+// that put the rows and columns of the biclique first, in increasing order,
+// followed by the remaining rows and columns in increasing order.  Columns are
+// given as indices [0, m) of the matrix.
+func (r *BiMaxResult) Permutation(n, m int) (rows, cols []int) {
+	return BiMaxResults{r}.Permutation(n, m)
+}
+
+// Submatrix returns the submatrix of the n by m matrix data made up of the rows
+// and columns of the biclique in increasing order.
+func (r *BiMaxResult) Submatrix(n, m int, data []uint8) []uint8 {
+	return BiMaxResults{r}.Submatrix(n, m, data)
+}
+
+// Permutation returns permutations of the rows and columns of an n by m matrix
+// that put the rows and columns of each biclique after those of the bicliques
+// before it, so that the bicliques are laid out along the diagonal from the
+// top left.  Within a biclique the rows and columns also in a later biclique
+// come last, ordered by the first such biclique, so that a biclique
+// overlapping the one before it is drawn as one block rather than split.
+// Otherwise rows and columns are in increasing order, and those in no
+// biclique come last.  Columns are given as indices [0, m) of the matrix.
+func (rr BiMaxResults) Permutation(n, m int) (rows, cols []int) {
+	rows, cols = rr.blocks(n)
+	return complete(rows, n), complete(cols, m)
+}
+
+// Submatrix returns the submatrix of the n by m matrix data made up of the rows
+// and columns in any of the bicliques, ordered as in 'Permutation'.  The
+// submatrix has one row for every row in a biclique and one column for every
+// column in a biclique.
+func (rr BiMaxResults) Submatrix(n, m int, data []uint8) []uint8 {
+	if len(data) != n*m {
+		panic(fmt.Sprintf("matrix data cannot be reshaped into [%d, %d]", n, m))
+	}
+	rows, cols := rr.blocks(n)
+	return Permute(m, data, rows, cols)
+}
+
+// blocks returns the rows and the columns of each biclique in turn, skipping
+// those already in an earlier biclique.  Within a biclique the rows and
+// columns shared with a later biclique come last, so that the overlap of
+// consecutive bicliques is contiguous.
+func (rr BiMaxResults) blocks(n int) (rows, cols []int) {
+	seenRows, seenCols := NewSet(), NewSet()
+	for k, r := range rr {
+		rows = append(rows, rr.block(k, r.Rows, seenRows, 0, func(r *BiMaxResult) *SetOp { return r.Rows })...)
+		cols = append(cols, rr.block(k, r.Cols, seenCols, n, func(r *BiMaxResult) *SetOp { return r.Cols })...)
+	}
+	return
+}
+
+// block returns the values of set less offset that are not yet seen, ordered
+// by the first biclique after the k-th that shares them, those shared with
+// none first, and then by value.
+func (rr BiMaxResults) block(k int, set *SetOp, seen *UnorderedSet, offset int, side func(*BiMaxResult) *SetOp) []int {
+	var vv []int
+	next := make(map[int]int)
+	for _, v := range sortedValues(set) {
+		if !seen.Add(v - offset) {
+			continue
+		}
+		vv = append(vv, v-offset)
+		for l := k + 1; l < len(rr); l++ {
+			if side(rr[l]).Has(v) {
+				next[v-offset] = l
+				break
+			}
+		}
+	}
+	sort.SliceStable(vv, func(i, j int) bool { return next[vv[i]] < next[vv[j]] })
+	return vv
+}
+
+// complete appends the indices [0, size) that are not yet in perm.
+func complete(perm []int, size int) []int {
+	seen := NewSetFromSlice(perm)
+	for i := 0; i < size; i++ {
+		if !seen.Has(i) {
+			perm = append(perm, i)
+		}
+	}
+	return perm
+}
+
+// Permute returns the matrix made up of the given rows and columns of a matrix
+// of m columns, in the given order.
+func Permute(m int, data []uint8, rows, cols []int) []uint8 {
+	result := make([]uint8, 0, len(rows)*len(cols))
+	for _, i := range rows {
+		for _, j := range cols {
+			result = append(result, data[i*m+j])
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package bimax_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maxsei/bimax"
+)
+
+func TestPermutation(t *testing.T) {
+	// Two bicliques interleaved in a 4 x 4 matrix.
+	data := []uint8{
+		0, 1, 0, 1,
+		1, 0, 1, 0,
+		0, 1, 0, 1,
+		1, 0, 1, 1,
+	}
+	results := bimax.BiMaxResults{
+		{Rows: bimax.NewSetWith(0, 2).SetOp, Cols: bimax.NewSetWith(5, 7).SetOp},
+		{Rows: bimax.NewSetWith(1, 3).SetOp, Cols: bimax.NewSetWith(4, 6).SetOp},
+	}
+	rows, cols := results[1].Permutation(4, 4)
+	if !reflect.DeepEqual(rows, []int{1, 3, 0, 2}) || !reflect.DeepEqual(cols, []int{0, 2, 1, 3}) {
+		t.Fatalf("got rows %v cols %v", rows, cols)
+	}
+	if sub := results[1].Submatrix(4, 4, data); !reflect.DeepEqual(sub, []uint8{1, 1, 1, 1}) {
+		t.Fatalf("got submatrix %v", sub)
+	}
+	rows, cols = results.Permutation(4, 4)
+	want := []uint8{
+		1, 1, 0, 0,
+		1, 1, 0, 0,
+		0, 0, 1, 1,
+		0, 1, 1, 1,
+	}
+	if got := bimax.Permute(4, data, rows, cols); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	if got := results.Submatrix(4, 4, data); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got submatrix %v want %v", got, want)
+	}
+}
+
+func TestPermutationOverlap(t *testing.T) {
+	// The second biclique shares row 1 and column 5 with the first, which are
+	// put last in the first so that the second is contiguous.
+	results := bimax.BiMaxResults{
+		{Rows: bimax.NewSetWith(0, 1, 2).SetOp, Cols: bimax.NewSetWith(4, 5).SetOp},
+		{Rows: bimax.NewSetWith(1, 3).SetOp, Cols: bimax.NewSetWith(5, 6, 7).SetOp},
+	}
+	rows, cols := results.Permutation(4, 4)
+	if !reflect.DeepEqual(rows, []int{0, 2, 1, 3}) || !reflect.DeepEqual(cols, []int{0, 1, 2, 3}) {
+		t.Fatalf("got rows %v cols %v", rows, cols)
+	}
+
+	// Sharing the first column of the first biclique instead moves it last.
+	results[0].Cols = bimax.NewSetWith(4, 5).SetOp
+	results[1].Cols = bimax.NewSetWith(4, 6).SetOp
+	if _, cols = results.Permutation(4, 4); !reflect.DeepEqual(cols, []int{1, 0, 2, 3}) {
+		t.Fatalf("got cols %v", cols)
+	}
+
+	// Rows shared with a later biclique are ordered by the first that shares
+	// them.
+	results = bimax.BiMaxResults{
+		{Rows: bimax.NewSetWith(0, 1, 2, 3).SetOp, Cols: bimax.NewSetWith(4).SetOp},
+		{Rows: bimax.NewSetWith(2, 4).SetOp, Cols: bimax.NewSetWith(5).SetOp},
+		{Rows: bimax.NewSetWith(0, 2, 5).SetOp, Cols: bimax.NewSetWith(6).SetOp},
+	}
+	if rows, _ = results.Permutation(6, 3); !reflect.DeepEqual(rows, []int{1, 3, 2, 0, 4, 5}) {
+		t.Fatalf("got rows %v", rows)
+	}
+}
@@ -0,0 +1,175 @@
+// Package table reads matrices from CSV and TSV files that have a header row
+// of column labels and a first column of row labels, such as gene by sample
+// expression matrices.
+package table
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultMissing are the tokens read as missing values when no others are
+// given.
+var DefaultMissing = []string{"", "NA", "NaN", "nan", "null"}
+
+// Options configures how a table is read.
+type Options struct {
+	// Comma is the field delimiter.  It defaults to ',' or, for 'ReadFile',
+	// to '\t' when the file name ends in .tsv or .tab.
+	Comma rune
+	// Missing are the tokens read as missing values.  It defaults to
+	// DefaultMissing.
+	Missing []string
+	// NoHeader is set when the first line holds data rather than column
+	// labels.
+	NoHeader bool
+	// NoIndex is set when the first column holds data rather than row labels.
+	NoIndex bool
+}
+
+// Matrix is an n by m matrix stored in row major order along with its labels.
+// Missing values are stored as NaN.
+type Matrix struct {
+	N, M      int
+	Data      []float64
+	RowLabels []string
+	ColLabels []string
+}
+
+// Read reads a matrix from r, which may be gzip compressed.
+func Read(r io.Reader, opts Options) (*Matrix, error) {
+	// Gzip streams are recognised by their magic number.
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = ','
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+	missing := make(map[string]bool)
+	if opts.Missing == nil {
+		opts.Missing = DefaultMissing
+	}
+	for _, token := range opts.Missing {
+		missing[token] = true
+	}
+	// Columns of data start after the row labels.
+	start := 1
+	if opts.NoIndex {
+		start = 0
+	}
+
+	x := &Matrix{}
+	for header := !opts.NoHeader; ; header = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Errors give the line a record starts on in the input, which
+		// differs from its number when quoted fields span lines.
+		line, _ := cr.FieldPos(0)
+		if len(record) < start {
+			return nil, fmt.Errorf("line %d: missing row label", line)
+		}
+		if header {
+			x.ColLabels = append([]string(nil), record[start:]...)
+			x.M = len(x.ColLabels)
+			continue
+		}
+		if x.N == 0 && x.M == 0 {
+			x.M = len(record) - start
+		}
+		if len(record)-start != x.M {
+			return nil, fmt.Errorf("line %d: %d values in a matrix of %d columns", line, len(record)-start, x.M)
+		}
+		if !opts.NoIndex {
+			x.RowLabels = append(x.RowLabels, record[0])
+		}
+		for k, field := range record[start:] {
+			field = strings.TrimSpace(field)
+			if missing[field] {
+				x.Data = append(x.Data, math.NaN())
+				continue
+			}
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				line, column := cr.FieldPos(start + k)
+				return nil, fmt.Errorf("line %d, column %d: %v", line, column, err)
+			}
+			x.Data = append(x.Data, v)
+		}
+		x.N++
+	}
+	return x, nil
+}
+
+// ReadFile reads a matrix from the named file, which may be gzip compressed.
+func ReadFile(name string, opts Options) (*Matrix, error) {
+	if opts.Comma == 0 {
+		switch filepath.Ext(strings.TrimSuffix(name, ".gz")) {
+		case ".tsv", ".tab":
+			opts.Comma = '\t'
+		}
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	x, err := Read(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return x, nil
+}
+
+// Binary returns the data of a binary matrix as taken by
+// 'bimax.BiMaxBinaryMatrix'.  Missing values are read as 0.
+func (x *Matrix) Binary() ([]uint8, error) {
+	data := make([]uint8, len(x.Data))
+	for i, v := range x.Data {
+		switch {
+		case v == 1:
+			data[i] = 1
+		case v == 0 || math.IsNaN(v):
+		default:
+			return nil, fmt.Errorf("value %v at row %d column %d is not a zero or 1", v, i/x.M, i%x.M)
+		}
+	}
+	return data, nil
+}
+
+// Threshold binarizes the matrix so that values greater than t are 1 and all
+// others, including missing values, are 0.
+func (x *Matrix) Threshold(t float64) []uint8 {
+	data := make([]uint8, len(x.Data))
+	for i, v := range x.Data {
+		if v > t {
+			data[i] = 1
+		}
+	}
+	return data
+}
@@ -0,0 +1,131 @@
+package table_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxsei/bimax/table"
+)
+
+// equal reports whether xx and yy are equal, taking NaNs as equal.
+func equal(xx, yy []float64) bool {
+	if len(xx) != len(yy) {
+		return false
+	}
+	for i := range xx {
+		if xx[i] != yy[i] && !(math.IsNaN(xx[i]) && math.IsNaN(yy[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRead(t *testing.T) {
+	nan := math.NaN()
+	for _, tc := range []struct {
+		name  string
+		input string
+		opts  table.Options
+		want  table.Matrix
+	}{
+		{
+			"labels", "gene,s1,s2\ng1,1,0.5\ng2, NA ,-2\n", table.Options{},
+			table.Matrix{N: 2, M: 2, Data: []float64{1, 0.5, nan, -2},
+				RowLabels: []string{"g1", "g2"}, ColLabels: []string{"s1", "s2"}},
+		},
+		{
+			"tabs", "\ta\tb\tc\nx\t1\t\t3\n", table.Options{Comma: '\t'},
+			table.Matrix{N: 1, M: 3, Data: []float64{1, nan, 3},
+				RowLabels: []string{"x"}, ColLabels: []string{"a", "b", "c"}},
+		},
+		{
+			"no labels", "1,0\n0,1\n1,1\n", table.Options{NoHeader: true, NoIndex: true},
+			table.Matrix{N: 3, M: 2, Data: []float64{1, 0, 0, 1, 1, 1}},
+		},
+		{
+			"missing tokens", "a,b\n-,1\n", table.Options{NoIndex: true, Missing: []string{"-"}},
+			table.Matrix{N: 1, M: 2, Data: []float64{nan, 1}, ColLabels: []string{"a", "b"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			x, err := table.Read(strings.NewReader(tc.input), tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if x.N != tc.want.N || x.M != tc.want.M || !equal(x.Data, tc.want.Data) ||
+				!reflect.DeepEqual(x.RowLabels, tc.want.RowLabels) || !reflect.DeepEqual(x.ColLabels, tc.want.ColLabels) {
+				t.Errorf("got %+v, want %+v", *x, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, input, want string
+	}{
+		{"ragged", ",a,b\nx,1,2\ny,1\n", "line 3: 1 values in a matrix of 2 columns"},
+		{"number", ",a,b\nx,1,2\ny,1,two\n", "line 3, column 5:"},
+		// Quoted fields span lines, so the fourth record is on the sixth
+		// line.
+		{"quoted", ",a,b\n\"x\ny\",1,2\nz,1,\"2\n\"\nw,oops,1\n", "line 6, column 3:"},
+		{"csv", ",a,b\nx,\"1,2\n", "line 2"},
+	} {
+		_, err := table.Read(strings.NewReader(tc.input), table.Options{})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("\ta\tb\nx\t1\t0\n"))
+	zw.Close()
+	name := filepath.Join(dir, "matrix.tsv.gz")
+	if err := os.WriteFile(name, gz.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The delimiter follows the extension under the gzip suffix.
+	x, err := table.ReadFile(name, table.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.N != 1 || x.M != 2 || !equal(x.Data, []float64{1, 0}) {
+		t.Errorf("got %+v", *x)
+	}
+
+	bad := filepath.Join(dir, "bad.csv")
+	if err := os.WriteFile(bad, []byte(",a\nx,1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.ReadFile(bad, table.Options{}); err == nil || !strings.HasPrefix(err.Error(), bad+": line 2:") {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	x := &table.Matrix{N: 2, M: 2, Data: []float64{1, 0, math.NaN(), 1}}
+	data, err := x.Binary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, []uint8{1, 0, 0, 1}) {
+		t.Errorf("got %v", data)
+	}
+	x.Data[1] = 0.5
+	if _, err := x.Binary(); err == nil || !strings.Contains(err.Error(), "row 0 column 1") {
+		t.Errorf("got error %v", err)
+	}
+	if got := x.Threshold(0.25); !reflect.DeepEqual(got, []uint8{1, 1, 0, 1}) {
+		t.Errorf("Threshold: got %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+// bimax.js loads bimax.wasm and wraps the functions it registers.
+//
+//   const bimax = await require("./bimax.js").load();
+//   bimax.binaryMatrix(2, 2, new Uint8Array([1, 1, 0, 1]));
+//   // {rows: [0], cols: [0, 1], weight: 2}
+//
+// In a browser include wasm_exec.js and bimax.js, then call
+// bimax.load(fetch("bimax.wasm")).  The functions throw an Error on invalid
+// input.
+(function (exports) {
+  "use strict";
+
+  // load instantiates the module from a Response, a promise of one, or its
+  // bytes.  In Node it defaults to bimax.wasm beside this file.
+  async function load(source) {
+    if (typeof Go === "undefined") {
+      require("./wasm_exec.js");
+    }
+    if (source === undefined) {
+      source = require("fs").readFileSync(require("path").join(__dirname, "bimax.wasm"));
+    }
+    source = await source;
+    const go = new Go();
+    const { instance } = typeof Response !== "undefined" && source instanceof Response
+      ? await WebAssembly.instantiateStreaming(source, go.importObject)
+      : await WebAssembly.instantiate(source, go.importObject);
+    // The program never exits so that its functions stay callable.
+    go.run(instance);
+    const api = globalThis.__bimax;
+    delete globalThis.__bimax;
+
+    const wrap = (f) => (...args) => {
+      const result = f(...args);
+      if (result.error !== undefined) {
+        throw new Error("bimax: " + result.error);
+      }
+      return result;
+    };
+    return {
+      binaryMatrix: wrap(api.binaryMatrix),
+      vertices: wrap(api.vertices),
+    };
+  }
+
+  exports.load = load;
+})(typeof module !== "undefined" ? module.exports : (globalThis.bimax = {}));
@@ -0,0 +1,124 @@
+//go:build js && wasm
+
+// Command wasm exposes bimax to JavaScript when built for GOOS=js
+// GOARCH=wasm.  Load it with bimax.js, which wraps the functions registered on
+// the global object __bimax:
+//
+//	binaryMatrix(n, m, data)  the largest biclique of an n by m binary matrix
+//	                          given as a Uint8Array or array in row major order
+//	vertices(uu, vv)          the largest biclique of the bipartite graph
+//	                          whose edges join uu[i] and vv[i]
+//
+// Both return a plain object {rows, cols, weight}.  The columns of a matrix
+// are column indices [0, m).  On invalid input they return {error} instead.
+package main
+
+import (
+	"fmt"
+	"sort"
+	"syscall/js"
+
+	"github.com/maxsei/bimax"
+)
+
+func main() {
+	js.Global().Set("__bimax", js.ValueOf(map[string]interface{}{
+		"binaryMatrix": js.FuncOf(guard(binaryMatrix)),
+		"vertices":     js.FuncOf(guard(vertices)),
+	}))
+	// The functions are only callable while the program runs.
+	select {}
+}
+
+// guard returns panics of f, which would otherwise end the program, as an
+// object {error}.
+func guard(f func(args []js.Value) interface{}) func(this js.Value, args []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = map[string]interface{}{"error": fmt.Sprint(r)}
+			}
+		}()
+		return f(args)
+	}
+}
+
+// ints copies a typed array or array of numbers.
+func ints(v js.Value) []int {
+	if v.Type() != js.TypeObject {
+		panic(fmt.Sprintf("%s is not an array", v.Type()))
+	}
+	n := v.Length()
+	vv := make([]int, n)
+	for i := range vv {
+		vv[i] = v.Index(i).Int()
+	}
+	return vv
+}
+
+// bytes copies a Uint8Array, or an array of numbers.
+func bytes(v js.Value) []uint8 {
+	if v.InstanceOf(js.Global().Get("Uint8Array")) || v.InstanceOf(js.Global().Get("Uint8ClampedArray")) {
+		data := make([]uint8, v.Length())
+		js.CopyBytesToGo(data, v)
+		return data
+	}
+	vv := ints(v)
+	data := make([]uint8, len(vv))
+	for i, x := range vv {
+		if x != 0 && x != 1 {
+			panic(fmt.Sprintf("%d is not a zero or 1", x))
+		}
+		data[i] = uint8(x)
+	}
+	return data
+}
+
+// object converts r to a plain object, shifting columns back by colOffset.
+func object(r *bimax.BiMaxResult, colOffset int) interface{} {
+	values := func(set *bimax.SetOp, offset int) []interface{} {
+		vv := set.Values()
+		sort.Ints(vv)
+		result := make([]interface{}, len(vv))
+		for i, v := range vv {
+			result[i] = v - offset
+		}
+		return result
+	}
+	return map[string]interface{}{
+		"rows":   values(r.Rows, 0),
+		"cols":   values(r.Cols, colOffset),
+		"weight": r.Weight,
+	}
+}
+
+func binaryMatrix(args []js.Value) interface{} {
+	if len(args) != 3 {
+		panic(fmt.Sprintf("binaryMatrix takes 3 arguments, not %d", len(args)))
+	}
+	n, m := args[0].Int(), args[1].Int()
+	if n <= 0 || m <= 0 {
+		panic(fmt.Sprintf("matrix [%d, %d] is empty", n, m))
+	}
+	data := bytes(args[2])
+	if len(data) != n*m {
+		panic(fmt.Sprintf("matrix data cannot be reshaped into [%d, %d]", n, m))
+	}
+	return object(bimax.BiMaxBinaryMatrix(n, m, data), n)
+}
+
+func vertices(args []js.Value) interface{} {
+	if len(args) != 2 {
+		panic(fmt.Sprintf("vertices takes 2 arguments, not %d", len(args)))
+	}
+	uu, vv := ints(args[0]), ints(args[1])
+	for _, v := range append(append([]int{}, uu...), vv...) {
+		if v < 0 {
+			panic(fmt.Sprintf("vertex %d is negative", v))
+		}
+	}
+	if len(uu) == 0 {
+		panic("empty edge list")
+	}
+	return object(bimax.BiMaxVertices(uu, vv), 0)
+}
@@ -0,0 +1,31 @@
+// test.js runs bimax.wasm headlessly in Node: make wasm-test
+"use strict";
+
+const assert = require("assert");
+const { load } = require("./bimax.js");
+
+(async () => {
+  const bimax = await load();
+
+  let r = bimax.binaryMatrix(3, 3, new Uint8Array([
+    1, 1, 0,
+    1, 1, 0,
+    0, 0, 1,
+  ]));
+  assert.deepStrictEqual(r, { rows: [0, 1], cols: [0, 1], weight: 4 });
+
+  r = bimax.binaryMatrix(2, 2, [1, 1, 0, 1]);
+  assert.strictEqual(r.weight, 2);
+
+  r = bimax.vertices(new Int32Array([0, 0, 1, 1, 1]), new Int32Array([2, 3, 2, 3, 4]));
+  assert.deepStrictEqual(r, { rows: [0, 1], cols: [2, 3], weight: 4 });
+
+  assert.throws(() => bimax.binaryMatrix(2, 2, [1, 2, 0, 1]), /2 is not a zero or 1/);
+  assert.throws(() => bimax.binaryMatrix(2, 3, new Uint8Array(4)), /cannot be reshaped/);
+  assert.throws(() => bimax.vertices([0, 1], [2]), /must be equal/);
+
+  console.log("ok");
+})().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
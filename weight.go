@@ -0,0 +1,105 @@
+package bimax
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yourbasic/graph"
+)
+
+// Objective scores a biclique made up of the vertices in rows and cols.
+// 'BiMaxObjective' reports the maximal biclique with the greatest score.  Only
+// maximal bicliques are ever scored so an objective should not decrease when
+// a vertex is added to a biclique, which holds for any sum of non-negative
+// weights.
+type Objective func(rows, cols *SetOp) float64
+
+// Area scores a biclique by the number of edges it contains, the number of
+// rows times the number of columns.
+func Area(rows, cols *SetOp) float64 {
+	return float64(rows.Card() * cols.Card())
+}
+
+// EdgeWeight returns an Objective that scores a biclique by the total weight
+// of its edges where w gives the weight of the edge between row u and column
+// v.
+func EdgeWeight(w func(u, v int) float64) Objective {
+	return func(rows, cols *SetOp) (total float64) {
+		rows.Each(func(u int) (_ bool) {
+			cols.Each(func(v int) (_ bool) {
+				total += w(u, v)
+				return
+			})
+			return
+		})
+		return
+	}
+}
+
+// EdgeCost returns an Objective that scores a biclique by the total cost of
+// its edges in G as added by 'AddCost' or 'AddBothCost'.
+func EdgeCost(G *graph.Mutable) Objective {
+	return EdgeWeight(func(u, v int) float64 {
+		return float64(G.Cost(u, v))
+	})
+}
+
+// VertexWeight returns an Objective that scores a biclique by the total weight
+// of its rows and columns where w gives the weight of a vertex.
+func VertexWeight(w func(v int) float64) Objective {
+	return func(rows, cols *SetOp) (total float64) {
+		sum := func(v int) (_ bool) {
+			total += w(v)
+			return
+		}
+		rows.Each(sum)
+		cols.Each(sum)
+		return
+	}
+}
+
+// BiMaxWeightedMatrix takes in an n by m weighted matrix where n is the number
+// of rows and m the number of columns.  Every non-zero entry is an edge whose
+// weight is the value of the entry.  The maximal biclique with the greatest
+// total edge weight is returned.
+func BiMaxWeightedMatrix(n, m int, data []float64) *BiMaxResult {
+	if (len(data) / m) != n {
+		panic(fmt.Sprintf("matrix data cannot be reshaped into [%d, %d]", n, m))
+	}
+	pattern := make([]uint8, len(data))
+	for i, x := range data {
+		if math.IsNaN(x) || x < 0 {
+			panic(fmt.Sprintf("%v is not a non-negative weight", x))
+		}
+		if x != 0 {
+			pattern[i] = 1
+		}
+	}
+	G, U, V := BinaryMatrixGraph(n, m, pattern)
+	// Rows are vertices [0, n) and columns are vertices [n, n+m) of G.
+	weight := func(u, v int) float64 { return data[u*m+v-n] }
+	return BiMaxObjective(G, U, V, EdgeWeight(weight))
+}
+
+// BiMaxVertexWeightedMatrix takes in an n by m binary matrix along with the
+// non-negative weight of each row and of each column.  The maximal biclique
+// with the greatest total weight of rows and columns is returned.
+func BiMaxVertexWeightedMatrix(n, m int, data []uint8, rowWeights, colWeights []float64) *BiMaxResult {
+	if len(rowWeights) != n || len(colWeights) != m {
+		panic(fmt.Sprintf("len(rowWeights): %d len(colWeights): %d must be %d and %d",
+			len(rowWeights), len(colWeights), n, m))
+	}
+	for _, x := range append(append([]float64{}, rowWeights...), colWeights...) {
+		if math.IsNaN(x) || x < 0 {
+			panic(fmt.Sprintf("%v is not a non-negative weight", x))
+		}
+	}
+	G, U, V := BinaryMatrixGraph(n, m, data)
+	weight := func(v int) float64 {
+		if v < n {
+			return rowWeights[v]
+		}
+		return colWeights[v-n]
+	}
+	return BiMaxObjective(G, U, V, VertexWeight(weight))
+}